@@ -14,6 +14,25 @@ const (
 	InputNameAudit          = "audit"          // System audit logs.
 )
 
+// InputNameEvents is the type of inputs collecting Kubernetes events. It is not a reserved
+// input name, events must be enabled by an input spec.
+const InputNameEvents = "events"
+
+// InputNameFile is the type of inputs collecting log files from the nodes. It is not a reserved
+// input name, files must be selected by an input spec.
+const InputNameFile = "file"
+
+// InputNameReceiver is the type of inputs receiving logs pushed by external producers. It is not a reserved
+// input name, receivers must be defined by an input spec.
+const InputNameReceiver = "receiver"
+
+// Receiver types.
+const (
+	ReceiverTypeHTTP         = "http"
+	ReceiverTypeSyslog       = "syslog"
+	ReceiverTypeKubeAPIAudit = "kubeAPIAudit"
+)
+
 var ReservedInputNames = sets.NewString(InputNameApplication, InputNameInfrastructure, InputNameAudit)
 
 func IsInputTypeName(s string) bool { return ReservedInputNames.Has(s) }
@@ -143,5 +162,14 @@ func (input *InputSpec) Types() sets.String {
 	if input.Audit != nil {
 		result.Insert(InputNameAudit)
 	}
+	if input.Events != nil {
+		result.Insert(InputNameEvents)
+	}
+	if input.File != nil {
+		result.Insert(InputNameFile)
+	}
+	if input.Receiver != nil {
+		result.Insert(InputNameReceiver)
+	}
 	return result
 }
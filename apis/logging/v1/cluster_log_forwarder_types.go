@@ -53,6 +53,190 @@ type ClusterLogForwarderSpec struct {
 	//
 	// +optional
 	OutputDefaults *OutputDefaults `json:"outputDefaults,omitempty"`
+
+	// AllowParserAnnotations enables parsing container log messages in the format
+	// declared by the `logging.openshift.io/parser.<container>` annotation of their pod.
+	//
+	// Supported formats are `json`, `klog` and `logfmt` (vector collector only).
+	// Parsed messages are stored in the `structured` field, other values are ignored.
+	//
+	// +optional
+	AllowParserAnnotations bool `json:"allowParserAnnotations,omitempty"`
+
+	// LevelDetection replaces the default detection of the `level` of container logs,
+	// which matches level names anywhere in the message.
+	//
+	// +optional
+	LevelDetection *LevelDetectionSpec `json:"levelDetection,omitempty"`
+
+	// Enrichment selects the kubernetes metadata attached to container logs in addition
+	// to the metadata of their pod.
+	//
+	// +optional
+	Enrichment *EnrichmentSpec `json:"enrichment,omitempty"`
+
+	// ClusterIdentity stamps the ID of the cluster, as read from its ClusterVersion, and the name
+	// of the cluster, as read from its Infrastructure, onto all records as `openshift.cluster_id`
+	// and `openshift.cluster_name`.
+	//
+	// +optional
+	ClusterIdentity bool `json:"clusterIdentity,omitempty"`
+
+	// HostAudit configures the processing of the linux audit logs of the nodes.
+	//
+	// Only supported by the vector collector.
+	//
+	// +optional
+	HostAudit *HostAuditSpec `json:"hostAudit,omitempty"`
+
+	// LogMetrics are Prometheus metrics derived from the records of pipelines, exported with the
+	// metrics of the collector.
+	//
+	// Only supported by the vector collector.
+	//
+	// +optional
+	LogMetrics []LogMetricSpec `json:"logMetrics,omitempty"`
+
+	// StaticFields are stamped onto all records. Keys are dot separated paths of the fields,
+	// for example `{"openshift.region": "eu-west-1"}`.
+	//
+	// +optional
+	StaticFields map[string]string `json:"staticFields,omitempty"`
+}
+
+// HostAuditSpec configures the processing of linux audit logs, whose key/value pairs are parsed into `audit.linux`.
+type HostAuditSpec struct {
+	// Correlate merges the records of an audit event sharing the same serial, such as `SYSCALL`, `CWD` and `PATH`
+	// records, into a single record. The values of the first record are kept for keys found in several records and
+	// the messages are joined by new lines.
+	//
+	// +optional
+	Correlate bool `json:"correlate,omitempty"`
+
+	// CorrelationWindow is the time in seconds to wait for the records of an event after its last record,
+	// unless the event ends with an `EOE` record. Defaults to 1 second.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=60
+	// +optional
+	CorrelationWindow int32 `json:"correlationWindow,omitempty"`
+}
+
+// LogMetricType is the type of a metric derived from logs.
+type LogMetricType string
+
+const (
+	// LogMetricTypeCounter counts the records.
+	LogMetricTypeCounter LogMetricType = "counter"
+	// LogMetricTypeHistogram observes the values of a numeric field of the records.
+	LogMetricTypeHistogram LogMetricType = "histogram"
+)
+
+// LogMetricSpec declares a metric derived from the records of pipelines.
+type LogMetricSpec struct {
+	// Name of the metric, exported with the `collector_` prefix.
+	//
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z_][a-zA-Z0-9_]*$"
+	// +required
+	Name string `json:"name"`
+
+	// Type of the metric.
+	//
+	// +kubebuilder:validation:Enum:=counter;histogram
+	// +required
+	Type LogMetricType `json:"type"`
+
+	// PipelineRefs lists the names of the pipelines whose records are measured.
+	//
+	// +kubebuilder:validation:MinItems:=1
+	// +required
+	PipelineRefs []string `json:"pipelineRefs"`
+
+	// Match is a regular expression, only the records whose message matches it are measured.
+	//
+	// +optional
+	Match string `json:"match,omitempty"`
+
+	// Field is the dot separated path of the numeric field observed by a histogram, for example `structured.duration`.
+	// Records without a numeric value are not measured.
+	//
+	// +optional
+	Field string `json:"field,omitempty"`
+
+	// Labels of the metric. Keys are label names and values are dot separated paths of the fields
+	// of the records, for example `{"namespace": "kubernetes.namespace_name", "level": "level"}`.
+	// Labels of records missing a field are empty. Each distinct set of values creates a series: fields whose
+	// values are unique to a record or a pod, such as `message`, timestamps, `kubernetes.pod_name` or
+	// `kubernetes.pod_id`, are rejected, and fields with many values increase the memory of the collector
+	// and of the monitoring stack. Invalid metrics are ignored and degrade the forwarder.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// EnrichmentSpec selects the metadata of the workload, namespace and node of a pod attached to its container logs.
+type EnrichmentSpec struct {
+	// Workload attaches the kind and name of the workload owning the pod, for example a `Deployment`,
+	// `StatefulSet`, `DaemonSet` or `Job`, to `kubernetes.workload_kind` and `kubernetes.workload_name`.
+	// Pods owned by a `ReplicaSet` of a `Deployment` are attributed to the `Deployment`.
+	//
+	// Only supported by the vector collector.
+	//
+	// +optional
+	Workload bool `json:"workload,omitempty"`
+
+	// NamespaceLabels attaches the labels of the namespace of the pod to `kubernetes.namespace_labels` when true,
+	// and removes them, with the other namespace metadata attached by fluentd, when false.
+	// The collector attaches its default namespace metadata when unset.
+	//
+	// +optional
+	NamespaceLabels *bool `json:"namespaceLabels,omitempty"`
+
+	// NodeLabels are the keys of the labels of the node of the pod attached to `kubernetes.node_labels`,
+	// for example `topology.kubernetes.io/zone` or `node.kubernetes.io/instance-type`.
+	//
+	// Only supported by the vector collector.
+	//
+	// +optional
+	NodeLabels []string `json:"nodeLabels,omitempty"`
+}
+
+// LevelDetectionSpec detects the level of container logs without a `level` field.
+type LevelDetectionSpec struct {
+	// Rules are evaluated in order until one detects a level, logs without a detected level
+	// have the level `default`.
+	//
+	// +kubebuilder:validation:MinItems:=1
+	// +required
+	Rules []LevelDetectionRule `json:"rules"`
+
+	// Mapping normalizes detected levels, for example `{"w": "warn", "fatal": "critical"}`.
+	// Detected levels and the keys of the mapping are compared in lower case, unmapped
+	// levels are used in lower case.
+	//
+	// +optional
+	Mapping map[string]string `json:"mapping,omitempty"`
+}
+
+// LevelDetectionRule detects the level of a log from one of its fields or its message.
+// One of `field` or `pattern` is required.
+type LevelDetectionRule struct {
+	// Field is the dot separated path of a field holding the level, for example `structured.severity`.
+	// Fields parsed from the message are available when parsed as declared by pod annotations.
+	//
+	// +optional
+	Field string `json:"field,omitempty"`
+
+	// Pattern is a regular expression matched against the message. The level is the value of
+	// the `level` named capture group, for example `^(?P<level>[IWEF])\d{4} `, or `level` if set.
+	//
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+
+	// Level is the level of the logs matching `pattern`.
+	//
+	// +optional
+	Level string `json:"level,omitempty"`
 }
 
 // ClusterLogForwarderStatus defines the observed state of ClusterLogForwarder
@@ -99,6 +283,24 @@ type InputSpec struct {
 	//
 	// +optional
 	Audit *Audit `json:"audit,omitempty"`
+
+	// Events, if present, enables collection of Kubernetes events.
+	// Events are gathered by an event router deployed by the operator.
+	//
+	// +optional
+	Events *Events `json:"events,omitempty"`
+
+	// File, if present, enables collection of log files written on the nodes under /var/log.
+	//
+	// +optional
+	File *File `json:"file,omitempty"`
+
+	// Receiver, if present, enables collection of logs pushed to the collector by external producers.
+	// The receiver is exposed by a service named `collector-<input name>` with a serving certificate
+	// issued by the service CA.
+	//
+	// +optional
+	Receiver *ReceiverSpec `json:"receiver,omitempty"`
 }
 
 // Application log selector.
@@ -117,13 +319,287 @@ type Application struct {
 	//
 	// +optional
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// AnnotatedFiles enables collection of the files listed by the `logging.openshift.io/files` annotation
+	// of the selected pods, a comma separated list of absolute path globs in the containers, for example
+	// `/data/logs/*.log`. The files must be written in `emptyDir` volumes, other paths are ignored. Annotated
+	// pods are resolved by the operator, which reconciles the collector configuration within a minute of a
+	// change: only the files of annotated pods are read, from their beginning.
+	// Only supported by the vector collector.
+	//
+	// +optional
+	AnnotatedFiles bool `json:"annotatedFiles,omitempty"`
+}
+
+// Infrastructure enables infrastructure logs.
+type Infrastructure struct {
+	// Journal filters the records read from the journal of the nodes. Container logs of infrastructure
+	// namespaces are not filtered.
+	//
+	// The journal is read once per node: all infrastructure inputs referenced by pipelines must define the
+	// same filter, and the `infrastructure` input cannot be referenced along with a filtered input.
+	//
+	// +optional
+	Journal *Journal `json:"journal,omitempty"`
+}
+
+// Journal selects the records of the node journal.
+// All conditions must be satisfied (logical AND) to select a record.
+type Journal struct {
+	// IncludeUnits are the systemd units from which to collect records, e.g. `kubelet.service`.
+	// Names without a suffix are completed with `.service`.
+	// If absent or empty, records are collected from all units.
+	//
+	// +optional
+	IncludeUnits []string `json:"includeUnits,omitempty"`
+
+	// ExcludeUnits are the systemd units from which records are dropped, e.g. `crio`.
+	// Names without a suffix are completed with `.service`.
+	//
+	// +optional
+	ExcludeUnits []string `json:"excludeUnits,omitempty"`
+
+	// MaxPriority is the least severe priority of the collected records.
+	// If absent, records of all priorities are collected.
+	//
+	// +kubebuilder:validation:Enum:=emerg;alert;crit;err;warning;notice;info;debug
+	// +optional
+	MaxPriority string `json:"maxPriority,omitempty"`
+
+	// Transports by which the collected records were received by the journal.
+	// If absent or empty, records are collected regardless of their transport.
+	//
+	// +optional
+	Transports []JournalTransport `json:"transports,omitempty"`
+}
+
+// JournalTransport is a transport by which the journal receives records.
+//
+// +kubebuilder:validation:Enum:=audit;driver;syslog;journal;stdout;kernel
+type JournalTransport string
+
+const (
+	JournalTransportAudit   JournalTransport = "audit"
+	JournalTransportDriver  JournalTransport = "driver"
+	JournalTransportSyslog  JournalTransport = "syslog"
+	JournalTransportJournal JournalTransport = "journal"
+	JournalTransportStdout  JournalTransport = "stdout"
+	JournalTransportKernel  JournalTransport = "kernel"
+)
+
+// JournalPriorities are the names of the journal priorities, from the most to the least severe.
+var JournalPriorities = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// Audit enables audit logs.
+type Audit struct {
+	// Filter selects the Kubernetes and OpenShift API audit events, other audit logs are not filtered.
+	// Only supported by the vector collector.
+	//
+	// +optional
+	Filter *AuditFilter `json:"filter,omitempty"`
+}
+
+// AuditFilter selects API audit events with rules modelled on the Kubernetes audit policy.
+// The first rule matching an event sets its level, events matching no rule are dropped.
+type AuditFilter struct {
+	// Rules matching the events, evaluated in order.
+	//
+	// +kubebuilder:validation:MinItems:=1
+	// +required
+	Rules []AuditRule `json:"rules"`
+
+	// OmitStages are the stages of the events dropped for all rules.
+	//
+	// +optional
+	OmitStages []AuditStage `json:"omitStages,omitempty"`
+}
+
+// AuditRule matches the API audit events satisfying all its conditions (logical AND).
+// Absent or empty conditions match all events.
+type AuditRule struct {
+	// Level of the matched events. Events are dropped at level `None`, their request and response objects
+	// are removed at level `Metadata` and their response object at level `Request`.
+	//
+	// +required
+	Level AuditLevel `json:"level"`
+
+	// Verbs of the requests, e.g. `create` or `delete`.
+	//
+	// +optional
+	Verbs []string `json:"verbs,omitempty"`
+
+	// Resources of the requests. Requests without resources, e.g. to `/healthz`, do not match if present.
+	//
+	// +optional
+	Resources []AuditGroupResources `json:"resources,omitempty"`
+
+	// Users making the requests.
+	//
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Namespaces of the requested objects.
+	//
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// OmitStages are the stages of the matched events that are dropped.
+	//
+	// +optional
+	OmitStages []AuditStage `json:"omitStages,omitempty"`
 }
 
-// Infrastructure enables infrastructure logs. Filtering may be added in future.
-type Infrastructure struct{}
+// AuditGroupResources are the resources of an API group.
+type AuditGroupResources struct {
+	// Group of the resources, empty for the core API group.
+	//
+	// +optional
+	Group string `json:"group,omitempty"`
 
-// Audit enables audit logs. Filtering may be added in future.
-type Audit struct{}
+	// Resources of the group, e.g. `secrets` or `pods/log` for a subresource.
+	// If absent or empty, all resources of the group match.
+	//
+	// +optional
+	Resources []string `json:"resources,omitempty"`
+}
+
+// AuditLevel is the amount of data recorded for an API audit event.
+//
+// +kubebuilder:validation:Enum:=None;Metadata;Request;RequestResponse
+type AuditLevel string
+
+const (
+	AuditLevelNone            AuditLevel = "None"
+	AuditLevelMetadata        AuditLevel = "Metadata"
+	AuditLevelRequest         AuditLevel = "Request"
+	AuditLevelRequestResponse AuditLevel = "RequestResponse"
+)
+
+// AuditStage is the stage of the request handling that generated an API audit event.
+//
+// +kubebuilder:validation:Enum:=RequestReceived;ResponseStarted;ResponseComplete;Panic
+type AuditStage string
+
+// Events selector of Kubernetes events.
+type Events struct {
+	// Namespaces of the involved objects from which to collect events.
+	// If absent or empty, events are collected from all namespaces.
+	//
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Deduplicate drops repeated updates of the same event that carry no new information.
+	//
+	// +optional
+	Deduplicate bool `json:"deduplicate,omitempty"`
+}
+
+// File selector of log files on the nodes.
+type File struct {
+	// Paths of the files to collect as glob patterns, e.g. `/var/log/myagent/*.log`.
+	// Paths must be absolute and below /var/log. Paths may not select the files collected by the built-in
+	// inputs: container logs in /var/log/pods and /var/log/containers, the journal, and the audit logs.
+	//
+	// +kubebuilder:validation:MinItems:=1
+	// +required
+	Paths []string `json:"paths"`
+
+	// Excludes are glob patterns of files matched by paths that are not collected.
+	//
+	// +optional
+	Excludes []string `json:"excludes,omitempty"`
+
+	// MultilineStartPattern is a regular expression matching the first line of a record.
+	// Lines not matching it are appended to the previous record.
+	// If absent, each line is a record.
+	//
+	// +optional
+	MultilineStartPattern string `json:"multilineStartPattern,omitempty"`
+
+	// LogType of the collected records, defaults to `infrastructure`.
+	//
+	// +kubebuilder:validation:Enum:=application;infrastructure
+	// +optional
+	LogType string `json:"logType,omitempty"`
+}
+
+// ReceiverSpec is a server of the collector receiving logs pushed by external producers.
+//
+// Receivers are exposed inside the cluster by a `ClusterIP` service named `collector-<input name>`, whose
+// serving certificate is issued by the service CA for the `collector-<input name>.<namespace>.svc` hostname.
+// Producers outside the cluster reach the receiver through a passthrough `Route` or a `LoadBalancer` service
+// created by the administrator for the collector pods, trusting the service CA and sending the service hostname
+// as TLS server name.
+type ReceiverSpec struct {
+	// Type of the receiver:
+	//   `http`: records in the body of POST requests over HTTPS
+	//   `syslog`: RFC3164 and RFC5424 syslog messages
+	//   `kubeAPIAudit`: `audit.k8s.io/v1` EventList batches posted over HTTPS by the audit webhook
+	//   backend of a Kubernetes API server, forwarded as the API audit logs of the nodes with the time
+	//   the requests were received as `@timestamp`
+	//
+	// +kubebuilder:validation:Enum:=http;syslog;kubeAPIAudit
+	// +required
+	Type string `json:"type"`
+
+	// Port the receiver listens on.
+	//
+	// +kubebuilder:validation:Minimum:=1024
+	// +kubebuilder:validation:Maximum:=65535
+	// +required
+	Port int32 `json:"port"`
+
+	// Secret authenticating the clients of the receiver, with the key:
+	//   `ca-bundle.crt`: clients must present a certificate signed by these CAs
+	//
+	// Required by the http and kubeAPIAudit receivers, optional for the syslog receiver over TCP.
+	// Connections of clients without a certificate signed by the CAs are refused by the receiver.
+	//
+	// +optional
+	Secret *OutputSecretSpec `json:"secret,omitempty"`
+
+	// HTTP receiver settings.
+	//
+	// +optional
+	HTTP *HTTPReceiver `json:"http,omitempty"`
+
+	// Syslog receiver settings.
+	//
+	// +optional
+	Syslog *SyslogReceiver `json:"syslog,omitempty"`
+
+	// KubeAPIAudit receiver settings.
+	//
+	// +optional
+	KubeAPIAudit *KubeAPIAuditReceiver `json:"kubeAPIAudit,omitempty"`
+}
+
+// KubeAPIAuditReceiver receives the audit events of a Kubernetes API server.
+type KubeAPIAuditReceiver struct {
+	// Filter selects the received audit events like the filter of an audit input.
+	//
+	// +optional
+	Filter *AuditFilter `json:"filter,omitempty"`
+}
+
+// HTTPReceiver receives logs in the body of POST requests.
+type HTTPReceiver struct {
+	// Format of the request bodies: `json` for an object or array of objects, `ndjson` for
+	// an object per line or `text` for a message per line. Defaults to `json`.
+	//
+	// +kubebuilder:validation:Enum:=json;ndjson;text
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+// SyslogReceiver receives RFC3164 and RFC5424 syslog messages.
+type SyslogReceiver struct {
+	// Protocol of the receiver: `tcp` for TLS over TCP or `udp` for unencrypted UDP. Defaults to `tcp`.
+	//
+	// +kubebuilder:validation:Enum:=tcp;udp
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+}
 
 // Output defines a destination for log messages.
 type OutputSpec struct {
@@ -156,6 +632,25 @@ type OutputSpec struct {
 	// +optional
 	URL string `json:"url,omitempty"`
 
+	// URLs to send log records to, for an output with more than one endpoint.
+	//
+	// Replaces `url`, set one or the other. All URLs must use the same scheme, which selects TLS
+	// as for `url`. Only supported by the fluentd collector for the elasticsearch and fluentdForward
+	// output types, which stop sending to an unavailable URL until it recovers.
+	//
+	// +optional
+	URLs []string `json:"urls,omitempty"`
+
+	// Strategy selects how log records are sent to the `urls` of the output.
+	//
+	// `roundRobin` (default) distributes records across the available URLs.
+	// `failover` sends records to the first URL and uses the others only while it is unavailable,
+	// it is only supported by the fluentdForward output type.
+	//
+	// +kubebuilder:validation:Enum:=failover;roundRobin
+	// +optional
+	Strategy OutputURLStrategy `json:"strategy,omitempty"`
+
 	OutputTypeSpec `json:",inline"`
 
 	// TLS contains settings for controlling options on TLS client connections.
@@ -202,6 +697,187 @@ type OutputSpec struct {
 	//
 	// +optional
 	Secret *OutputSecretSpec `json:"secret,omitempty"`
+
+	// Tuning controls how records are batched and delivered to this output.
+	//
+	// Tuning applies to this output only and takes precedence over collector wide settings
+	// such as the fluentd buffer spec. Options not supported by the output type or the
+	// collector are ignored.
+	//
+	// +optional
+	Tuning *OutputTuningSpec `json:"tuning,omitempty"`
+
+	// FallbackOutputRef names an output which would receive the records rejected by this output,
+	// with the rejection reason attached.
+	//
+	// Not supported yet and rejected by both collectors: vector sinks do not emit the records a
+	// destination rejects, and fluentd hands undelivered chunks to its <secondary> section without
+	// the error of the destination.
+	//
+	// +optional
+	FallbackOutputRef string `json:"fallbackOutputRef,omitempty"`
+
+	// DataModel is the data model of the records sent to the output, `viaq` by default.
+	//
+	// `viaq`: the OpenShift logging data model.
+	// `otel`: the OpenTelemetry log data model and semantic conventions. `@timestamp`, `message` and `level` become
+	// `timestamp`, `body` and `severity_text`, `log_type` becomes `attributes.log_type`, the host, cluster, node,
+	// namespace, pod and container metadata move below `resource`, for example `resource.k8s.pod.name`, and the other
+	// `kubernetes` and `openshift` fields move below `attributes`.
+	// `ecs`: the Elastic Common Schema. `level`, `log_type`, `hostname` and `openshift.labels` become `log.level`,
+	// `event.dataset`, `host.name` and `labels`, the cluster, namespace and pod metadata move below `orchestrator`,
+	// the container metadata below `container`, and the other `kubernetes` and `openshift` fields are kept as custom fields.
+	//
+	// Other top level fields are unchanged by the translation.
+	//
+	// Other data models are supported by `elasticsearch`, `kafka` and `fluentdForward` (fluentd collector only) outputs.
+	//
+	// +kubebuilder:validation:Enum:=viaq;otel;ecs
+	// +optional
+	DataModel DataModelType `json:"dataModel,omitempty"`
+}
+
+type DataModelType string
+
+const (
+	DataModelViaQ DataModelType = "viaq"
+	DataModelOTel DataModelType = "otel"
+	DataModelECS  DataModelType = "ecs"
+)
+
+// OutputTuningSpec contains options to tune the delivery of records that are agnostic to the collector.
+type OutputTuningSpec struct {
+	// MaxBatchBytes is the maximum size in bytes of a batch of records sent in a single request.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxBatchBytes int64 `json:"maxBatchBytes,omitempty"`
+
+	// MaxBatchEvents is the maximum number of records sent in a single request.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxBatchEvents int32 `json:"maxBatchEvents,omitempty"`
+
+	// BatchTimeout is the maximum time in seconds a batch of records is held before it is sent.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	BatchTimeout int32 `json:"batchTimeout,omitempty"`
+
+	// RequestTimeout is the time in seconds to wait for a request to the output to complete.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	RequestTimeout int32 `json:"requestTimeout,omitempty"`
+
+	// MinRetryDuration is the time in seconds to wait before the first retry of a failed request.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MinRetryDuration int32 `json:"minRetryDuration,omitempty"`
+
+	// MaxRetryDuration is the maximum time in seconds to wait between retries of a failed request.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxRetryDuration int32 `json:"maxRetryDuration,omitempty"`
+
+	// Concurrency is the maximum number of requests in flight to the output.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	Concurrency int32 `json:"concurrency,omitempty"`
+
+	// Compression of the payload of requests to the output.
+	//
+	// Supported compression depends on the output type and the collector:
+	// `gzip` is supported by elasticsearch for both collectors, and by
+	// cloudwatch and loki for vector. `gzip`, `snappy`, `lz4` and `zstd` are
+	// supported by kafka. fluentdForward supports `gzip` for fluentd.
+	//
+	// +kubebuilder:validation:Enum:=none;gzip;snappy;lz4;zstd
+	// +optional
+	Compression string `json:"compression,omitempty"`
+
+	// DeliveryMode of records to the output. (Default: AtMostOnce)
+	//
+	// AtLeastOnce enables end-to-end acknowledgements: the collector only advances its
+	// read position once records are acknowledged by the output, so records are not lost
+	// when the output or the collector restarts but may be delivered more than once.
+	// Only supported by the vector collector.
+	//
+	// +kubebuilder:validation:Enum:=AtLeastOnce;AtMostOnce
+	// +optional
+	DeliveryMode OutputDeliveryMode `json:"deliveryMode,omitempty"`
+
+	// Buffer configures how records are buffered by the collector before they are sent to the output.
+	// Only supported by the vector collector, fluentd always uses file buffers.
+	//
+	// +optional
+	Buffer *OutputBufferSpec `json:"buffer,omitempty"`
+}
+
+const (
+	// OutputDeliveryModeAtLeastOnce retries records until they are acknowledged by the output
+	OutputDeliveryModeAtLeastOnce OutputDeliveryMode = "AtLeastOnce"
+	// OutputDeliveryModeAtMostOnce does not wait for acknowledgements, records may be lost on restarts
+	OutputDeliveryModeAtMostOnce OutputDeliveryMode = "AtMostOnce"
+)
+
+type OutputDeliveryMode string
+
+const (
+	// OutputURLStrategyFailover sends records to the first available URL of an output
+	OutputURLStrategyFailover OutputURLStrategy = "failover"
+	// OutputURLStrategyRoundRobin distributes records across the available URLs of an output
+	OutputURLStrategyRoundRobin OutputURLStrategy = "roundRobin"
+)
+
+type OutputURLStrategy string
+
+const (
+	// OutputBufferTypeMemory buffers records in memory, they are lost when the collector restarts
+	OutputBufferTypeMemory OutputBufferType = "memory"
+	// OutputBufferTypeDisk buffers records on the node, they survive restarts of the collector
+	OutputBufferTypeDisk OutputBufferType = "disk"
+)
+
+type OutputBufferType string
+
+// MinDiskBufferMaxSize is the smallest maximum size of a disk buffer accepted by the vector collector
+const MinDiskBufferMaxSize int64 = 268435488
+
+const (
+	// BufferWhenFullBlock blocks processing inputs when the buffer is full
+	BufferWhenFullBlock BufferWhenFullType = "block"
+	// BufferWhenFullDropNewest drops records arriving when the buffer is full
+	BufferWhenFullDropNewest BufferWhenFullType = "drop_newest"
+)
+
+type BufferWhenFullType string
+
+// OutputBufferSpec configures the buffer of an output.
+type OutputBufferSpec struct {
+	// Type of the buffer. A disk buffer is kept in the collector data directory
+	// on the node. (Default: memory)
+	//
+	// +kubebuilder:validation:Enum:=memory;disk
+	// +optional
+	Type OutputBufferType `json:"type,omitempty"`
+
+	// MaxSize is the maximum size in bytes of a disk buffer, at least 268435488. Only supported by disk buffers.
+	// (Default: 1073741824)
+	//
+	// +kubebuilder:validation:Minimum:=268435488
+	// +optional
+	MaxSize int64 `json:"maxSize,omitempty"`
+
+	// WhenFull is the action taken when the buffer is full. (Default: block)
+	//
+	// +kubebuilder:validation:Enum:=block;drop_newest
+	// +optional
+	WhenFull BufferWhenFullType `json:"whenFull,omitempty"`
 }
 
 // OutputTLSSpec contains options for TLS connections that are agnostic to the output type.
@@ -262,12 +938,163 @@ type PipelineSpec struct {
 	// +optional
 	Parse string `json:"parse,omitempty"`
 
+	// Parser parses the message of log records in one of several formats.
+	//
+	// Takes precedence over `parse`.
+	//
+	// +optional
+	Parser *PipelineParserSpec `json:"parser,omitempty"`
+
+	// StructuredFields protects the structured fields of records from type conflicts,
+	// which the log store rejects when it indexes differently typed values of a field.
+	//
+	// Applies to the target field of the parser, `structured` by default.
+	//
+	// +optional
+	StructuredFields *StructuredFieldsSpec `json:"structuredFields,omitempty"`
+
+	// Timestamp takes the `@timestamp` of logs from their content instead of the time they were collected.
+	//
+	// +optional
+	Timestamp *TimestampSpec `json:"timestamp,omitempty"`
+
 	// DetectMultilineErrors enables multiline error detection of container logs
 	//
 	// +optional
 	DetectMultilineErrors bool `json:"detectMultilineErrors,omitempty"`
 }
 
+// PipelineParserSpec parses the message of log records into structured fields.
+type PipelineParserSpec struct {
+	// Type is the format of the message.
+	//
+	// `json`: a JSON object.
+	// `logfmt`: space separated key=value pairs, only supported by the vector collector.
+	// `regex`: matched by `pattern`, each named capture group becomes a field.
+	// `klog`: the format of the Kubernetes klog library, parsed into the `level` (`I`, `W`, `E` or `F`), `timestamp`,
+	// `id`, `file`, `line` and `message` string fields.
+	// `syslog`: an RFC 3164 or RFC 5424 syslog message.
+	// `csv`: comma separated values, named by `headers`.
+	//
+	// +kubebuilder:validation:Enum:=json;logfmt;regex;klog;syslog;csv
+	// +required
+	Type ParserType `json:"type"`
+
+	// Pattern is the regular expression of the `regex` parser, for example
+	// `^(?P<client>\S+) \S+ \S+ \[(?P<time>[^\]]+)\] "(?P<request>[^"]*)" (?P<status>\d+)`
+	//
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+
+	// Headers name the columns of the `csv` parser, in order.
+	//
+	// +optional
+	Headers []string `json:"headers,omitempty"`
+
+	// TargetField is the top level field of the record receiving the parsed fields, `structured` by default.
+	//
+	// +kubebuilder:validation:Pattern:=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	// +optional
+	TargetField string `json:"targetField,omitempty"`
+
+	// KeepOriginal keeps the `message` field of the records which were parsed, it is removed by default.
+	// Records which cannot be parsed are forwarded unchanged.
+	//
+	// +optional
+	KeepOriginal bool `json:"keepOriginal,omitempty"`
+}
+
+// TimestampSpec extracts the time of logs from their content. One of `field` or `pattern` is required.
+//
+// Logs keep the time they were collected when their time cannot be parsed, the unparsed time
+// is then recorded in the `unparsed_timestamp` field.
+type TimestampSpec struct {
+	// Field is the dot separated path of a field holding the time, for example `structured.time`.
+	//
+	// +optional
+	Field string `json:"field,omitempty"`
+
+	// Pattern is a regular expression matched against the message. The time is the value of
+	// the `timestamp` named capture group, for example `^\[(?P<timestamp>[^\]]+)\]`.
+	//
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+
+	// Format is the strftime format of the time, for example `%d/%b/%Y:%H:%M:%S %z`.
+	// Times are parsed as RFC 3339 by default.
+	//
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// Timezone is the IANA name of the timezone of times formatted without an offset, for example `Europe/Paris`.
+	// Such times are in UTC by default. A timezone requires a format.
+	//
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// UnparsedTimestampField records the time extracted from the content of logs when it cannot be parsed
+const UnparsedTimestampField = "unparsed_timestamp"
+
+// StructuredFieldsSpec is the policy applied to the structured fields of records.
+type StructuredFieldsSpec struct {
+	// Types coerces the declared fields to a type, keyed by their dot separated path
+	// in the structured fields. Values which cannot be coerced are removed.
+	//
+	// +optional
+	Types map[string]FieldType `json:"types,omitempty"`
+
+	// Conflicts protects the undeclared top level structured fields with a string, number or boolean value.
+	//
+	// `none`: fields are unchanged, the default.
+	// `suffix`: the type of the value is appended to the name of the field, for example `status_str`, `status_num` or `status_bool`.
+	// `stringify`: values are converted to strings.
+	//
+	// +kubebuilder:validation:Enum:=none;suffix;stringify
+	// +optional
+	Conflicts StructuredFieldConflicts `json:"conflicts,omitempty"`
+}
+
+// +kubebuilder:validation:Enum:=string;integer;float;boolean;timestamp
+type FieldType string
+
+const (
+	FieldTypeString    FieldType = "string"
+	FieldTypeInteger   FieldType = "integer"
+	FieldTypeFloat     FieldType = "float"
+	FieldTypeBoolean   FieldType = "boolean"
+	FieldTypeTimestamp FieldType = "timestamp"
+)
+
+type StructuredFieldConflicts string
+
+const (
+	StructuredFieldConflictsNone      StructuredFieldConflicts = "none"
+	StructuredFieldConflictsSuffix    StructuredFieldConflicts = "suffix"
+	StructuredFieldConflictsStringify StructuredFieldConflicts = "stringify"
+)
+
+type ParserType string
+
+const (
+	ParserTypeJSON   ParserType = "json"
+	ParserTypeLogfmt ParserType = "logfmt"
+	ParserTypeRegex  ParserType = "regex"
+	ParserTypeKlog   ParserType = "klog"
+	ParserTypeSyslog ParserType = "syslog"
+	ParserTypeCSV    ParserType = "csv"
+
+	// DefaultParserTargetField receives the parsed fields unless the parser names another field
+	DefaultParserTargetField = "structured"
+
+	// ParserAnnotationPrefix prefixes the container name in pod annotations declaring the format of its logs
+	ParserAnnotationPrefix = "logging.openshift.io/parser."
+
+	// PodFilesAnnotation lists the files written by a pod in emptyDir volumes, as absolute path globs in its
+	// containers, collected by application inputs enabling annotated files
+	PodFilesAnnotation = "logging.openshift.io/files"
+)
+
 type OutputDefaults struct {
 
 	// Elasticsearch OutputSpec default values
@@ -254,5 +254,20 @@ type GoogleCloudLogging struct {
 	ProjectID string `json:"projectId,omitempty"`
 
 	//LogID is the log ID to which to publish logs. This identifies log stream.
+	//
+	// The log ID may reference record fields, which are replaced by their value
+	// for each record. For example, to publish to a log per namespace:
+	//
+	//     logId: app-{.kubernetes.namespace_name}
+	//
+	// Records are published with a severity mapped from their `level` and with a
+	// `k8s_container` monitored resource for container logs, or a `k8s_node`
+	// monitored resource for node logs.
 	LogID string `json:"logId,omitempty"`
+
+	// Location is the `location` label of the monitored resources, for example the region of the cluster.
+	// Defaults to the region of the cluster on GCP, otherwise to `global`.
+	//
+	// +optional
+	Location string `json:"location,omitempty"`
 }
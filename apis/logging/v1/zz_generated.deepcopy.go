@@ -42,6 +42,11 @@ func (in *Application) DeepCopy() *Application {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Audit) DeepCopyInto(out *Audit) {
 	*out = *in
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(AuditFilter)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Audit.
@@ -54,6 +59,95 @@ func (in *Audit) DeepCopy() *Audit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditFilter) DeepCopyInto(out *AuditFilter) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]AuditRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OmitStages != nil {
+		in, out := &in.OmitStages, &out.OmitStages
+		*out = make([]AuditStage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditFilter.
+func (in *AuditFilter) DeepCopy() *AuditFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditGroupResources) DeepCopyInto(out *AuditGroupResources) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditGroupResources.
+func (in *AuditGroupResources) DeepCopy() *AuditGroupResources {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditGroupResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditRule) DeepCopyInto(out *AuditRule) {
+	*out = *in
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]AuditGroupResources, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OmitStages != nil {
+		in, out := &in.OmitStages, &out.OmitStages
+		*out = make([]AuditStage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditRule.
+func (in *AuditRule) DeepCopy() *AuditRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cloudwatch) DeepCopyInto(out *Cloudwatch) {
 	*out = *in
@@ -183,6 +277,35 @@ func (in *ClusterLogForwarderSpec) DeepCopyInto(out *ClusterLogForwarderSpec) {
 		*out = new(OutputDefaults)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LevelDetection != nil {
+		in, out := &in.LevelDetection, &out.LevelDetection
+		*out = new(LevelDetectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Enrichment != nil {
+		in, out := &in.Enrichment, &out.Enrichment
+		*out = new(EnrichmentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostAudit != nil {
+		in, out := &in.HostAudit, &out.HostAudit
+		*out = new(HostAuditSpec)
+		**out = **in
+	}
+	if in.LogMetrics != nil {
+		in, out := &in.LogMetrics, &out.LogMetrics
+		*out = make([]LogMetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StaticFields != nil {
+		in, out := &in.StaticFields, &out.StaticFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLogForwarderSpec.
@@ -679,6 +802,31 @@ func (in *ElasticsearchStatus) DeepCopy() *ElasticsearchStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnrichmentSpec) DeepCopyInto(out *EnrichmentSpec) {
+	*out = *in
+	if in.NamespaceLabels != nil {
+		in, out := &in.NamespaceLabels, &out.NamespaceLabels
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnrichmentSpec.
+func (in *EnrichmentSpec) DeepCopy() *EnrichmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnrichmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventCollectionSpec) DeepCopyInto(out *EventCollectionSpec) {
 	*out = *in
@@ -709,6 +857,51 @@ func (in *EventCollectionStatus) DeepCopy() *EventCollectionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Events) DeepCopyInto(out *Events) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Events.
+func (in *Events) DeepCopy() *Events {
+	if in == nil {
+		return nil
+	}
+	out := new(Events)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *File) DeepCopyInto(out *File) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Excludes != nil {
+		in, out := &in.Excludes, &out.Excludes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new File.
+func (in *File) DeepCopy() *File {
+	if in == nil {
+		return nil
+	}
+	out := new(File)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluentdBufferSpec) DeepCopyInto(out *FluentdBufferSpec) {
 	*out = *in
@@ -920,9 +1113,44 @@ func (in *GoogleCloudLogging) DeepCopy() *GoogleCloudLogging {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPReceiver) DeepCopyInto(out *HTTPReceiver) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPReceiver.
+func (in *HTTPReceiver) DeepCopy() *HTTPReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostAuditSpec) DeepCopyInto(out *HostAuditSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostAuditSpec.
+func (in *HostAuditSpec) DeepCopy() *HostAuditSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostAuditSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Infrastructure) DeepCopyInto(out *Infrastructure) {
 	*out = *in
+	if in.Journal != nil {
+		in, out := &in.Journal, &out.Journal
+		*out = new(Journal)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Infrastructure.
@@ -946,12 +1174,27 @@ func (in *InputSpec) DeepCopyInto(out *InputSpec) {
 	if in.Infrastructure != nil {
 		in, out := &in.Infrastructure, &out.Infrastructure
 		*out = new(Infrastructure)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Audit != nil {
 		in, out := &in.Audit, &out.Audit
 		*out = new(Audit)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = new(Events)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(File)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Receiver != nil {
+		in, out := &in.Receiver, &out.Receiver
+		*out = new(ReceiverSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -965,6 +1208,36 @@ func (in *InputSpec) DeepCopy() *InputSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Journal) DeepCopyInto(out *Journal) {
+	*out = *in
+	if in.IncludeUnits != nil {
+		in, out := &in.IncludeUnits, &out.IncludeUnits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeUnits != nil {
+		in, out := &in.ExcludeUnits, &out.ExcludeUnits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transports != nil {
+		in, out := &in.Transports, &out.Transports
+		*out = make([]JournalTransport, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Journal.
+func (in *Journal) DeepCopy() *Journal {
+	if in == nil {
+		return nil
+	}
+	out := new(Journal)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Kafka) DeepCopyInto(out *Kafka) {
 	*out = *in
@@ -1077,6 +1350,68 @@ func (in *KibanaStatus) DeepCopy() *KibanaStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeAPIAuditReceiver) DeepCopyInto(out *KubeAPIAuditReceiver) {
+	*out = *in
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(AuditFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeAPIAuditReceiver.
+func (in *KubeAPIAuditReceiver) DeepCopy() *KubeAPIAuditReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeAPIAuditReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LevelDetectionRule) DeepCopyInto(out *LevelDetectionRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LevelDetectionRule.
+func (in *LevelDetectionRule) DeepCopy() *LevelDetectionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LevelDetectionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LevelDetectionSpec) DeepCopyInto(out *LevelDetectionSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]LevelDetectionRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mapping != nil {
+		in, out := &in.Mapping, &out.Mapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LevelDetectionSpec.
+func (in *LevelDetectionSpec) DeepCopy() *LevelDetectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LevelDetectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogCollectionSpec) DeepCopyInto(out *LogCollectionSpec) {
 	*out = *in
@@ -1109,6 +1444,33 @@ func (in *LogCollectionStatus) DeepCopy() *LogCollectionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogMetricSpec) DeepCopyInto(out *LogMetricSpec) {
+	*out = *in
+	if in.PipelineRefs != nil {
+		in, out := &in.PipelineRefs, &out.PipelineRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogMetricSpec.
+func (in *LogMetricSpec) DeepCopy() *LogMetricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogMetricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogStoreSpec) DeepCopyInto(out *LogStoreSpec) {
 	*out = *in
@@ -1231,6 +1593,21 @@ func (in *NormalizerStatus) DeepCopy() *NormalizerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputBufferSpec) DeepCopyInto(out *OutputBufferSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputBufferSpec.
+func (in *OutputBufferSpec) DeepCopy() *OutputBufferSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputBufferSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutputDefaults) DeepCopyInto(out *OutputDefaults) {
 	*out = *in
@@ -1269,6 +1646,11 @@ func (in *OutputSecretSpec) DeepCopy() *OutputSecretSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutputSpec) DeepCopyInto(out *OutputSpec) {
 	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.OutputTypeSpec.DeepCopyInto(&out.OutputTypeSpec)
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
@@ -1280,6 +1662,11 @@ func (in *OutputSpec) DeepCopyInto(out *OutputSpec) {
 		*out = new(OutputSecretSpec)
 		**out = **in
 	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(OutputTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputSpec.
@@ -1307,6 +1694,26 @@ func (in *OutputTLSSpec) DeepCopy() *OutputTLSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputTuningSpec) DeepCopyInto(out *OutputTuningSpec) {
+	*out = *in
+	if in.Buffer != nil {
+		in, out := &in.Buffer, &out.Buffer
+		*out = new(OutputBufferSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputTuningSpec.
+func (in *OutputTuningSpec) DeepCopy() *OutputTuningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputTuningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutputTypeSpec) DeepCopyInto(out *OutputTypeSpec) {
 	*out = *in
@@ -1357,6 +1764,26 @@ func (in *OutputTypeSpec) DeepCopy() *OutputTypeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineParserSpec) DeepCopyInto(out *PipelineParserSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineParserSpec.
+func (in *PipelineParserSpec) DeepCopy() *PipelineParserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineParserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 	*out = *in
@@ -1377,6 +1804,21 @@ func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Parser != nil {
+		in, out := &in.Parser, &out.Parser
+		*out = new(PipelineParserSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StructuredFields != nil {
+		in, out := &in.StructuredFields, &out.StructuredFields
+		*out = new(StructuredFieldsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = new(TimestampSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSpec.
@@ -1438,6 +1880,41 @@ func (in *ProxySpec) DeepCopy() *ProxySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiverSpec) DeepCopyInto(out *ReceiverSpec) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(OutputSecretSpec)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPReceiver)
+		**out = **in
+	}
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(SyslogReceiver)
+		**out = **in
+	}
+	if in.KubeAPIAudit != nil {
+		in, out := &in.KubeAPIAudit, &out.KubeAPIAudit
+		*out = new(KubeAPIAuditReceiver)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiverSpec.
+func (in *ReceiverSpec) DeepCopy() *ReceiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetentionPoliciesSpec) DeepCopyInto(out *RetentionPoliciesSpec) {
 	*out = *in
@@ -1568,6 +2045,28 @@ func (in *Routes) DeepCopy() *Routes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StructuredFieldsSpec) DeepCopyInto(out *StructuredFieldsSpec) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make(map[string]FieldType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StructuredFieldsSpec.
+func (in *StructuredFieldsSpec) DeepCopy() *StructuredFieldsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StructuredFieldsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Syslog) DeepCopyInto(out *Syslog) {
 	*out = *in
@@ -1583,6 +2082,36 @@ func (in *Syslog) DeepCopy() *Syslog {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogReceiver) DeepCopyInto(out *SyslogReceiver) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogReceiver.
+func (in *SyslogReceiver) DeepCopy() *SyslogReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimestampSpec) DeepCopyInto(out *TimestampSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimestampSpec.
+func (in *TimestampSpec) DeepCopy() *TimestampSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TimestampSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VisualizationSpec) DeepCopyInto(out *VisualizationSpec) {
 	*out = *in
@@ -2,10 +2,12 @@ package forwarding
 
 import (
 	"context"
+	"time"
 
 	log "github.com/ViaQ/logerr/v2/log/static"
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	"github.com/openshift/cluster-logging-operator/internal/constants"
+	"github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	"github.com/openshift/cluster-logging-operator/internal/k8shandler"
 	"github.com/openshift/cluster-logging-operator/internal/status"
 	"github.com/openshift/cluster-logging-operator/internal/telemetry"
@@ -26,11 +28,17 @@ var _ reconcile.Reconciler = &ReconcileForwarder{}
 type ReconcileForwarder struct {
 	// This Client, initialized using mgr.Client() above, is a split Client
 	// that reads objects from the cache and writes to the apiserver
-	Client   client.Client
+	Client client.Client
+	// Reader is an initialized client.Reader that reads objects directly from the apiserver
+	Reader   client.Reader
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
 }
 
+// podFilesResyncPeriod is the period of the reconciliation resolving the files listed by pod annotations,
+// since pods of other namespaces are not watched
+const podFilesResyncPeriod = time.Minute
+
 var condReady = status.Condition{Type: logging.ConditionReady, Status: corev1.ConditionTrue}
 
 func condNotReady(r status.ConditionReason, format string, args ...interface{}) status.Condition {
@@ -66,7 +74,7 @@ func (r *ReconcileForwarder) Reconcile(ctx context.Context, request ctrl.Request
 
 	log.V(3).Info("clusterlogforwarder-controller run reconciler...")
 
-	reconcileErr := k8shandler.ReconcileForClusterLogForwarder(instance, r.Client)
+	reconcileErr := k8shandler.ReconcileForClusterLogForwarder(instance, r.Client, r.Reader)
 	if reconcileErr != nil {
 		// if cluster is set to fail to reconcile then set healthStatus as 0
 		telemetry.ResetCLFMetricsNoErr()
@@ -119,6 +127,9 @@ func (r *ReconcileForwarder) Reconcile(ctx context.Context, request ctrl.Request
 		return result, err
 	}
 
+	if helpers.HasAnnotatedFiles(&instance.Spec) {
+		return ctrl.Result{RequeueAfter: podFilesResyncPeriod}, reconcileErr
+	}
 	return ctrl.Result{}, reconcileErr
 }
 
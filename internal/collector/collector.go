@@ -14,6 +14,7 @@ import (
 	"github.com/openshift/cluster-logging-operator/internal/constants"
 	"github.com/openshift/cluster-logging-operator/internal/factory"
 	coreFactory "github.com/openshift/cluster-logging-operator/internal/factory"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	"github.com/openshift/cluster-logging-operator/internal/utils"
 )
 
@@ -160,6 +161,12 @@ func (f *Factory) NewPodSpec(trustedCABundle *v1.ConfigMap, forwarderSpec loggin
 
 	addVolumesForCloudwatch(collector, podSpec, forwarderSpec, f.Secrets)
 
+	addVolumesForFileInputs(collector, podSpec, forwarderSpec)
+
+	addVolumesForPodFiles(collector, podSpec, forwarderSpec)
+
+	addReceivers(collector, podSpec, forwarderSpec)
+
 	podSpec.Containers = []v1.Container{
 		*collector,
 		*exporter,
@@ -236,7 +243,7 @@ func newLogMetricsExporterContainer() *v1.Container {
 	return &exporter
 }
 
-// addSecretVolumes adds secret volumes to the pod spec for the unique set of pipeline secrets and returns the list of
+// addSecretVolumes adds secret volumes to the pod spec for the unique set of output and receiver secrets and returns the list of
 // the secret names
 func addSecretVolumes(podSpec *v1.PodSpec, pipelineSpec logging.ClusterLogForwarderSpec) []string {
 	// List of _unique_ output secret names, several outputs may use the same secret.
@@ -246,6 +253,11 @@ func addSecretVolumes(podSpec *v1.PodSpec, pipelineSpec logging.ClusterLogForwar
 			unique.Insert(o.Secret.Name)
 		}
 	}
+	for _, input := range genhelper.ReceiverInputs(&pipelineSpec) {
+		if input.Receiver.Secret != nil && input.Receiver.Secret.Name != "" {
+			unique.Insert(input.Receiver.Secret.Name)
+		}
+	}
 	secretNames := unique.List()
 	for _, name := range secretNames {
 		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{Name: name, VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: name}}})
@@ -171,6 +171,98 @@ var _ = Describe("Factory#NewPodSpec", func() {
 				verifyProxyVolumesAndVolumeMounts(collector, podSpec, constants.CollectorTrustedCAName)
 			})
 		})
+
+		Context("and file inputs are defined", func() {
+			It("should mount read-only the directories of the files that are not mounted already", func() {
+				podSpec = *factory.NewPodSpec(nil, logging.ClusterLogForwarderSpec{
+					Inputs: []logging.InputSpec{
+						{
+							Name: "vendor",
+							File: &logging.File{Paths: []string{"/var/log/vendor/*.log", "/var/log/vendor/agent/agent.log"}},
+						},
+						{
+							Name: "other",
+							File: &logging.File{Paths: []string{"/var/log/other/*/*.log", "/var/log/audit/extra.log"}},
+						},
+					},
+				})
+				collector = podSpec.Containers[0]
+				Expect(collector.VolumeMounts).To(ContainElements(
+					v1.VolumeMount{Name: "varlogfileinput0", ReadOnly: true, MountPath: "/var/log/other"},
+					v1.VolumeMount{Name: "varlogfileinput1", ReadOnly: true, MountPath: "/var/log/vendor"},
+				))
+				Expect(podSpec.Volumes).To(ContainElements(
+					v1.Volume{Name: "varlogfileinput0", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/var/log/other"}}},
+					v1.Volume{Name: "varlogfileinput1", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/var/log/vendor"}}},
+				))
+				Expect(podSpec.Volumes).ToNot(ContainElement(HaveField("Name", "varlogfileinput2")))
+			})
+		})
+
+		Context("and receiver inputs are defined", func() {
+			It("should expose the receiver ports and mount the serving certificates and client secrets", func() {
+				podSpec = *factory.NewPodSpec(nil, logging.ClusterLogForwarderSpec{
+					Inputs: []logging.InputSpec{
+						{
+							Name: "myapp",
+							Receiver: &logging.ReceiverSpec{
+								Type:   logging.ReceiverTypeHTTP,
+								Port:   8443,
+								Secret: &logging.OutputSecretSpec{Name: "myapp-clients"},
+							},
+						},
+					},
+					Pipelines: []logging.PipelineSpec{
+						{InputRefs: []string{"myapp"}, OutputRefs: []string{logging.OutputNameDefault}},
+					},
+				})
+				collector = podSpec.Containers[0]
+				Expect(collector.Ports).To(ContainElement(v1.ContainerPort{Name: "receiver-8443", ContainerPort: 8443, Protocol: v1.ProtocolTCP}))
+				Expect(collector.VolumeMounts).To(ContainElements(
+					v1.VolumeMount{Name: "collector-myapp", ReadOnly: true, MountPath: "/etc/collector/receiver/myapp"},
+					v1.VolumeMount{Name: "myapp-clients", ReadOnly: true, MountPath: "/var/run/ocp-collector/secrets/myapp-clients"},
+				))
+				Expect(podSpec.Volumes).To(ContainElements(
+					v1.Volume{Name: "collector-myapp", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "collector-myapp"}}},
+					v1.Volume{Name: "myapp-clients", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "myapp-clients"}}},
+				))
+			})
+		})
+
+		Context("and application inputs collect annotated pod files", func() {
+			It("should mount read-only the volumes of the pods propagating mounts from the host", func() {
+				podSpec = *factory.NewPodSpec(nil, logging.ClusterLogForwarderSpec{
+					Inputs: []logging.InputSpec{
+						{
+							Name:        "myapp",
+							Application: &logging.Application{AnnotatedFiles: true},
+						},
+					},
+					Pipelines: []logging.PipelineSpec{
+						{InputRefs: []string{"myapp"}, OutputRefs: []string{logging.OutputNameDefault}},
+					},
+				})
+				collector = podSpec.Containers[0]
+				propagation := v1.MountPropagationHostToContainer
+				Expect(collector.VolumeMounts).To(ContainElement(
+					v1.VolumeMount{Name: "varlibkubeletpods", ReadOnly: true, MountPath: "/var/lib/kubelet/pods", MountPropagation: &propagation},
+				))
+				Expect(podSpec.Volumes).To(ContainElement(
+					v1.Volume{Name: "varlibkubeletpods", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/var/lib/kubelet/pods"}}},
+				))
+			})
+			It("should not mount the volumes of the pods when the inputs are not referenced", func() {
+				podSpec = *factory.NewPodSpec(nil, logging.ClusterLogForwarderSpec{
+					Inputs: []logging.InputSpec{
+						{
+							Name:        "myapp",
+							Application: &logging.Application{AnnotatedFiles: true},
+						},
+					},
+				})
+				Expect(podSpec.Volumes).ToNot(ContainElement(HaveField("Name", "varlibkubeletpods")))
+			})
+		})
 	})
 
 })
@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"fmt"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	v1 "k8s.io/api/core/v1"
+)
+
+// hostLogDirs are the directories of the nodes always mounted into the collector
+var hostLogDirs = []string{
+	logContainersValue,
+	logPodsValue,
+	logJournalValue,
+	logAuditValue,
+	logOvnValue,
+	logOauthapiserverValue,
+	logOpenshiftapiserverValue,
+	logKubeapiserverValue,
+}
+
+// addVolumesForFileInputs mounts read-only the directories of the nodes read by file inputs
+// that are not already mounted
+func addVolumesForFileInputs(collector *v1.Container, podSpec *v1.PodSpec, forwarderSpec logging.ClusterLogForwarderSpec) {
+	i := 0
+	for _, dir := range genhelper.FileInputDirs(forwarderSpec) {
+		if genhelper.IsWithinDirs(dir, hostLogDirs) {
+			continue
+		}
+		name := fmt.Sprintf("varlogfileinput%d", i)
+		i++
+		collector.VolumeMounts = append(collector.VolumeMounts, v1.VolumeMount{Name: name, ReadOnly: true, MountPath: dir})
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{Name: name, VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: dir}}})
+	}
+}
@@ -0,0 +1,20 @@
+package collector
+
+import (
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	v1 "k8s.io/api/core/v1"
+)
+
+const podVolumes = "varlibkubeletpods"
+
+// addVolumesForPodFiles mounts read-only the volumes of the pods of the nodes when an application input collects
+// the files listed by pod annotations. Mounts of memory backed volumes are propagated from the host.
+func addVolumesForPodFiles(collector *v1.Container, podSpec *v1.PodSpec, forwarderSpec logging.ClusterLogForwarderSpec) {
+	if !genhelper.HasAnnotatedFiles(&forwarderSpec) {
+		return
+	}
+	propagation := v1.MountPropagationHostToContainer
+	collector.VolumeMounts = append(collector.VolumeMounts, v1.VolumeMount{Name: podVolumes, ReadOnly: true, MountPath: genhelper.PodVolumesDir, MountPropagation: &propagation})
+	podSpec.Volumes = append(podSpec.Volumes, v1.Volume{Name: podVolumes, VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: genhelper.PodVolumesDir}}})
+}
@@ -0,0 +1,41 @@
+package collector
+
+import (
+	"fmt"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	v1 "k8s.io/api/core/v1"
+)
+
+// IsReservedPort returns true if the port is used by the containers of the collector pod
+func IsReservedPort(port int32) bool {
+	return port == metricsPort || port == exporterPort
+}
+
+// ReceiverPortName returns the name of the container port of a receiver input
+func ReceiverPortName(port int32) string {
+	return fmt.Sprintf("receiver-%d", port)
+}
+
+// ReceiverProtocol returns the protocol of the port of a receiver input
+func ReceiverProtocol(receiver *logging.ReceiverSpec) v1.Protocol {
+	if genhelper.IsUDPReceiver(receiver) {
+		return v1.ProtocolUDP
+	}
+	return v1.ProtocolTCP
+}
+
+// addReceivers exposes the ports of the receiver inputs and mounts their serving certificates
+func addReceivers(collector *v1.Container, podSpec *v1.PodSpec, forwarderSpec logging.ClusterLogForwarderSpec) {
+	for _, input := range genhelper.ReceiverInputs(&forwarderSpec) {
+		name := genhelper.ReceiverServiceName(input.Name)
+		collector.Ports = append(collector.Ports, v1.ContainerPort{
+			Name:          ReceiverPortName(input.Receiver.Port),
+			ContainerPort: input.Receiver.Port,
+			Protocol:      ReceiverProtocol(input.Receiver),
+		})
+		collector.VolumeMounts = append(collector.VolumeMounts, v1.VolumeMount{Name: name, ReadOnly: true, MountPath: genhelper.ReceiverCertPath(input.Name, "")})
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{Name: name, VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: name}}})
+	}
+}
@@ -68,6 +68,7 @@ const (
 	CuratorName                = "curator"
 	LogfilesmetricexporterName = "logfilesmetricexporter"
 	ConsolePluginName          = "consoleplugin"
+	EventRouterName            = "eventrouter"
 	LogStoreURL                = "https://" + ElasticsearchFQDN + ":" + ElasticsearchPort
 	MasterCASecretName         = "master-certs"
 	CollectorSecretName        = "collector"
@@ -90,8 +91,10 @@ const (
 	VectorImageEnvVar             = "RELATED_IMAGE_VECTOR"
 	LogfilesmetricImageEnvVar     = "RELATED_IMAGE_LOG_FILE_METRIC_EXPORTER"
 	ConsolePluginImageEnvVar      = "RELATED_IMAGE_LOGGING_CONSOLE_PLUGIN"
+	EventRouterImageEnvVar        = "RELATED_IMAGE_EVENTROUTER"
 	CertEventName                 = "cluster-logging-certs-generate"
 	ClusterInfrastructureInstance = "cluster"
+	ClusterVersionInstance        = "version"
 
 	ContainerLogDir = "/var/log/containers"
 	PodLogDir       = "/var/log/pods"
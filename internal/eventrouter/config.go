@@ -0,0 +1,37 @@
+package eventrouter
+
+import (
+	"fmt"
+
+	"github.com/openshift/cluster-logging-operator/internal/constants"
+	"github.com/openshift/cluster-logging-operator/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Config is the configuration struct for the event router Reconciler.
+// Construct with NewConfig to set default values.
+type Config struct {
+	Owner client.Object // Owning object - the ClusterLogging instance
+	Name  string        // Name for the event router and related objects
+	Image string        // Image for the event router.
+
+	NodeSelector map[string]string   // Node selector of the event router pod, linux nodes are always selected
+	Tolerations  []corev1.Toleration // Tolerations of the event router pod
+}
+
+func (cf *Config) Namespace() string   { return cf.Owner.GetNamespace() }
+func (cf *Config) CreatedBy() string   { return fmt.Sprintf("%v_%v", cf.Namespace(), cf.Owner.GetName()) }
+func (cf *Config) defaultMode() *int32 { return utils.GetInt32(420) }
+
+// clusterName is the name of the cluster scoped objects of the event router
+func (cf *Config) clusterName() string { return fmt.Sprintf("%v-%v", cf.Namespace(), cf.Name) }
+
+// NewConfig returns a config with default settings.
+func NewConfig(owner client.Object) Config {
+	return Config{
+		Owner: owner,
+		Name:  constants.EventRouterName,
+		Image: utils.GetComponentImage(constants.EventRouterName),
+	}
+}
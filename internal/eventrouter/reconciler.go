@@ -0,0 +1,241 @@
+package eventrouter
+
+import (
+	"context"
+
+	log "github.com/ViaQ/logerr/v2/log/static"
+	"github.com/openshift/cluster-logging-operator/internal/constants"
+	"github.com/openshift/cluster-logging-operator/internal/runtime"
+	"github.com/openshift/cluster-logging-operator/internal/utils"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Reconciler reconciles the event router state with the desired configuration.
+// The event router watches Kubernetes events and writes them to stdout where
+// they are picked up by the collector like any other container log.
+type Reconciler struct {
+	Config
+	c client.Client
+
+	serviceAccount     corev1.ServiceAccount
+	clusterRole        rbacv1.ClusterRole
+	clusterRoleBinding rbacv1.ClusterRoleBinding
+	configMap          corev1.ConfigMap
+	deployment         appv1.Deployment
+}
+
+// NewReconciler creates a Reconciler using client for config.
+func NewReconciler(c client.Client, cf Config) *Reconciler {
+	r := &Reconciler{Config: cf, c: c}
+	_ = r.each(func(m mutable) error {
+		runtime.Initialize(m.o, r.Namespace(), r.Name)
+		return nil
+	})
+	// Cluster scoped objects are named after the namespace, to be unique across ClusterLogging instances.
+	runtime.Initialize(&r.clusterRole, "", r.clusterName())
+	runtime.Initialize(&r.clusterRoleBinding, "", r.clusterName())
+	return r
+}
+
+// Reconcile creates or updates cluster objects to match config.
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	modified := false
+	// Call CreateOrUpdate for each object.
+	err := r.each(func(m mutable) error {
+		return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			result, err := controllerutil.CreateOrUpdate(ctx, r.c, m.o, m.mutate)
+			if err == nil && result != controllerutil.OperationResultNone {
+				modified = true
+				log.V(2).Info("reconciled", "object", runtime.ID(m.o), "action", result)
+			}
+			return err
+		})
+	})
+	if err != nil {
+		log.Error(err, "reconciling event router", "deployment", runtime.ID(&r.deployment))
+		_ = r.Delete(ctx) // Clear out any partial setup
+		return err
+	}
+	if modified {
+		log.Info("reconciled event router", "deployment", runtime.ID(&r.deployment))
+	}
+	return nil
+}
+
+// Delete the event router and related objects.
+func (r *Reconciler) Delete(ctx context.Context) error {
+	var errs []error // Collect errors, don't stop on first.
+	_ = r.each(func(m mutable) error {
+		err := r.c.Delete(ctx, m.o)
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+			log.Error(err, "deleting event router", "object", runtime.ID(m.o))
+		}
+		return nil // Don't stop on first error.
+	})
+	return utilerrors.NewAggregate(errs)
+}
+
+// each calls f for each object. Stops on first error and returns it.
+func (r *Reconciler) each(f func(m mutable) error) error {
+	for _, m := range []mutable{
+		{&r.serviceAccount, r.mutateServiceAccount},
+		{&r.clusterRole, r.mutateClusterRole},
+		{&r.clusterRoleBinding, r.mutateClusterRoleBinding},
+		{&r.configMap, r.mutateConfigMap},
+		{&r.deployment, r.mutateDeployment},
+	} {
+		if err := f(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mutable is an object and a mutate function that sets the desired state on the object.
+// Suitable to be passed to controllerutil.CreateOrUpdate
+type mutable struct {
+	o      client.Object
+	mutate controllerutil.MutateFn
+}
+
+// mutateOwned sets common labels and the owner for owned objects.
+func (r *Reconciler) mutateOwned(o client.Object) error {
+	r.mutateClusterScoped(o)
+	return controllerutil.SetControllerReference(r.Owner, o, r.c.Scheme())
+}
+
+// mutateClusterScoped sets common labels for cluster scoped objects. They cannot be owned by the
+// namespaced ClusterLogging instance, Delete removes them.
+func (r *Reconciler) mutateClusterScoped(o client.Object) {
+	o.SetLabels(map[string]string{
+		constants.LabelApp:          r.Name,
+		constants.LabelK8sName:      r.Name,
+		constants.LabelK8sCreatedBy: r.CreatedBy(),
+	})
+}
+
+// mutateServiceAccount sets the service account of the event router, distinct from the privileged
+// collector service account.
+func (r *Reconciler) mutateServiceAccount() error {
+	return r.mutateOwned(&r.serviceAccount)
+}
+
+// mutateClusterRole only allows to read events, the event router needs nothing else.
+func (r *Reconciler) mutateClusterRole() error {
+	o := &r.clusterRole
+	o.Rules = []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"events"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}
+	r.mutateClusterScoped(o)
+	return nil
+}
+
+func (r *Reconciler) mutateClusterRoleBinding() error {
+	o := &r.clusterRoleBinding
+	o.RoleRef = rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     r.clusterName(),
+	}
+	o.Subjects = []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      r.Name,
+			Namespace: r.Namespace(),
+		},
+	}
+	r.mutateClusterScoped(o)
+	return nil
+}
+
+func (r *Reconciler) mutateConfigMap() error {
+	o := &r.configMap
+	o.Data = map[string]string{
+		"config.json": `{
+  "sink": "stdout"
+}`,
+	}
+	return r.mutateOwned(o)
+}
+
+// selector map used by the deployment. The collector identifies event records by these labels.
+func (r *Reconciler) selector() map[string]string {
+	return map[string]string{constants.LabelK8sName: r.Name, constants.LabelK8sCreatedBy: r.CreatedBy()}
+}
+
+func (r *Reconciler) mutateDeployment() error {
+	o := &r.deployment
+	podLabels := r.selector()
+	podLabels["logging-infra"] = r.Name
+	o.Spec = appv1.DeploymentSpec{
+		// A single replica, more would emit every event more than once.
+		Replicas: utils.GetInt32(1),
+		Selector: &metav1.LabelSelector{MatchLabels: r.selector()},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: r.Name,
+				NodeSelector:       utils.EnsureLinuxNodeSelector(r.NodeSelector),
+				Tolerations:        r.Tolerations,
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot:   utils.GetBool(true),
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				},
+				Containers: []corev1.Container{
+					{
+						Name:  r.Name,
+						Image: r.Image,
+						SecurityContext: &corev1.SecurityContext{
+							ReadOnlyRootFilesystem:   utils.GetBool(true),
+							AllowPrivilegeEscalation: utils.GetBool(false),
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+						},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("100m"),
+								corev1.ResourceMemory: resource.MustParse("128Mi"),
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{
+								Name:      "config-volume",
+								ReadOnly:  true,
+								MountPath: "/etc/eventrouter",
+							},
+						},
+					},
+				},
+				Volumes: []corev1.Volume{
+					{
+						Name: "config-volume",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: r.Name},
+								DefaultMode:          r.defaultMode(),
+							},
+						},
+					},
+				},
+				RestartPolicy: "Always",
+				DNSPolicy:     "ClusterFirst",
+			},
+		},
+		// Recreate so that two routers never run side by side.
+		Strategy: appv1.DeploymentStrategy{Type: appv1.RecreateDeploymentStrategyType},
+	}
+	return r.mutateOwned(o)
+}
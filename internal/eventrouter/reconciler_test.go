@@ -0,0 +1,119 @@
+package eventrouter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openshift/cluster-logging-operator/internal/constants"
+	"github.com/openshift/cluster-logging-operator/internal/utils"
+	"github.com/openshift/cluster-logging-operator/test/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() { utils.InitLogger("test") }
+
+func fakeClient() client.Client { return fake.NewClientBuilder().WithScheme(scheme.Scheme).Build() }
+
+var ctx = context.Background()
+
+// assertConfig asserts that the Reconciler config matches the cluster state.
+func assertConfig(t *testing.T, r *Reconciler) {
+	c := r.c
+	d := &r.deployment
+	if assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(d), d)) {
+		assert.Equal(t, int32(1), *d.Spec.Replicas)
+		assert.Equal(t, r.Image, d.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, r.Name, d.Spec.Template.Spec.ServiceAccountName)
+		assert.Equal(t, r.Name, d.Spec.Template.Labels[constants.LabelK8sName])
+		assert.Equal(t, utils.EnsureLinuxNodeSelector(r.NodeSelector), d.Spec.Template.Spec.NodeSelector)
+		assert.Equal(t, r.Tolerations, d.Spec.Template.Spec.Tolerations)
+		if assert.NotNil(t, d.Spec.Template.Spec.SecurityContext) {
+			assert.True(t, *d.Spec.Template.Spec.SecurityContext.RunAsNonRoot)
+		}
+		if sc := d.Spec.Template.Spec.Containers[0].SecurityContext; assert.NotNil(t, sc) {
+			assert.False(t, *sc.AllowPrivilegeEscalation)
+			assert.True(t, *sc.ReadOnlyRootFilesystem)
+		}
+	}
+
+	cm := &r.configMap
+	if assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(cm), cm)) {
+		assert.JSONEq(t, `{"sink": "stdout"}`, cm.Data["config.json"])
+	}
+
+	cr := &r.clusterRole
+	if assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(cr), cr)) {
+		assert.Equal(t, []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: []string{"get", "list", "watch"}}}, cr.Rules)
+	}
+
+	crb := &r.clusterRoleBinding
+	if assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(crb), crb)) {
+		assert.Equal(t, cr.Name, crb.RoleRef.Name)
+		assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: r.Name, Namespace: r.Namespace()}}, crb.Subjects)
+	}
+
+	for _, o := range []client.Object{&r.deployment, &r.configMap, &r.serviceAccount} {
+		o := o
+		kind := o.GetObjectKind().GroupVersionKind().Kind
+		if assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(o), o), kind) {
+			assert.Equal(t, r.Name, o.GetName(), kind)
+			assert.Equal(t, r.Namespace(), o.GetNamespace(), kind)
+			assert.Equal(t, r.CreatedBy(), o.GetLabels()[constants.LabelK8sCreatedBy])
+			if assert.Len(t, o.GetOwnerReferences(), 1, kind) {
+				oref := o.GetOwnerReferences()[0]
+				assert.Equal(t, oref.Name, r.Owner.GetName(), kind)
+				require.NotNil(t, oref.Controller, kind)
+				assert.True(t, *oref.Controller, kind)
+			}
+		}
+	}
+}
+
+// assertNotFound asserts that none of the objects for Config r exist.
+func assertNotFound(t *testing.T, r *Reconciler) {
+	c := r.c
+	t.Helper()
+	assert.NoError(t, r.each(func(m mutable) error {
+		err := c.Get(ctx, client.ObjectKeyFromObject(m.o), m.o)
+		assert.True(t, apierrors.IsNotFound(err), "expected not-found %v, got: %v", client.ObjectKeyFromObject(m.o), err)
+		return nil
+	}))
+}
+
+func TestReconcileCreatesObjects(t *testing.T) {
+	c := fakeClient()
+	r := NewReconciler(c, NewConfig(runtime.NewClusterLogging()))
+	require.NoError(t, r.Reconcile(ctx))
+	assertConfig(t, r)
+}
+
+func TestReconcileUpdatesObjects(t *testing.T) {
+	c := fakeClient()
+	r := NewReconciler(c, NewConfig(runtime.NewClusterLogging()))
+	require.NoError(t, r.Reconcile(ctx)) // Create objects
+	assertConfig(t, r)
+
+	// Modify configuration
+	r.Image = "newimage"
+	r.NodeSelector = map[string]string{"node-role.kubernetes.io/infra": ""}
+	r.Tolerations = []corev1.Toleration{{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists}}
+	require.NoError(t, r.Reconcile(ctx))
+	assertConfig(t, r)
+}
+
+func TestReconcilerDeletesObjects(t *testing.T) {
+	c := fakeClient()
+	r := NewReconciler(c, NewConfig(runtime.NewClusterLogging()))
+	require.NoError(t, r.Reconcile(ctx)) // Create objects
+	assertConfig(t, r)
+
+	require.NoError(t, r.Delete(ctx))
+	assertNotFound(t, r)
+}
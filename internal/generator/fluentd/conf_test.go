@@ -263,7 +263,9 @@ var _ = Describe("Testing Complete Config Generation", func() {
     <record>
       @timestamp ${DateTime.parse(record['message'].split('|')[0]).rfc3339(6)}
       level ${record['message'].split('|')[3].downcase}
+      _dummy_ ${m = record['message'].to_s.match(/\|acl_log\([^)]*\)\|[^|]*\|name="?(?<name>[^",]*)"?, verdict=(?<verdict>[^,:]+)(?:, severity=(?<severity>[^,:]+))?(?:, direction=(?<direction>[^,:]+))?(?:: (?<flow>.*))?$/); if m; flow = m['flow'].to_s.split(','); fields = flow.map { |f| f.split('=', 2) }.select { |f| f.size == 2 }.to_h; record['ovn'] = {'acl_name' => m['name'], 'verdict' => m['verdict'], 'severity' => m['severity'], 'direction' => m['direction'], 'protocol' => flow[0], 'src_ip' => fields['nw_src'] || fields['ipv6_src'], 'dst_ip' => fields['nw_dst'] || fields['ipv6_dst'], 'src_port' => (fields['tp_src'].to_i if fields['tp_src']), 'dst_port' => (fields['tp_dst'].to_i if fields['tp_dst'])}.compact; end; nil}
     </record>
+    remove_keys _dummy_
   </filter>
 
   # Process Kube and OpenShift Audit logs
@@ -0,0 +1,44 @@
+package fluentd
+
+import (
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+)
+
+// KubernetesMetadata adds the kubernetes metadata of the pod, and of its namespace unless skipped, to container records
+type KubernetesMetadata struct {
+	Desc                  string
+	AnnotationMatch       string
+	SkipNamespaceMetadata bool
+}
+
+func (km KubernetesMetadata) Name() string {
+	return "kubernetesMetadata"
+}
+
+func (km KubernetesMetadata) Template() string {
+	return `{{define "` + km.Name() + `" -}}
+# {{.Desc}}
+<filter kubernetes.**>
+  @id kubernetes-metadata
+  @type kubernetes_metadata
+  kubernetes_url 'https://kubernetes.default.svc'
+  annotation_match {{.AnnotationMatch}}
+  allow_orphans false
+  cache_size '1000'
+  ssl_partial_chain 'true'
+{{- if .SkipNamespaceMetadata}}
+  skip_namespace_metadata true
+{{- end}}
+</filter>
+{{end}}`
+}
+
+// NewKubernetesMetadata returns the kubernetes metadata filter, the metadata of namespaces is
+// skipped only if the enrichment opts out of namespace labels
+func NewKubernetesMetadata(spec *logging.ClusterLogForwarderSpec) KubernetesMetadata {
+	return KubernetesMetadata{
+		Desc:                  "Invoke kubernetes apiserver to get kubernetes metadata",
+		AnnotationMatch:       AnnotationMatch(spec),
+		SkipNamespaceMetadata: spec.Enrichment != nil && spec.Enrichment.NamespaceLabels != nil && !*spec.Enrichment.NamespaceLabels,
+	}
+}
@@ -10,6 +10,13 @@ import (
 
 var Replacer = strings.NewReplacer(" ", "_", "-", "_", ".", "_")
 
+var rubyQuote = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// RubyString returns the single quoted ruby literal of a string
+func RubyString(value string) string {
+	return fmt.Sprintf("'%s'", rubyQuote.Replace(value))
+}
+
 func LabelName(name string) string {
 	return strings.ToUpper(fmt.Sprintf("@%s", Replacer.Replace(name)))
 }
@@ -43,3 +50,13 @@ func LabelsKV(ls *metav1.LabelSelector) []string {
 func StoreID(prefix, name, suffix string) string {
 	return strings.ToLower(fmt.Sprintf("%v%v%v", prefix, Replacer.Replace(name), suffix))
 }
+
+// RecordPath returns the ruby expression assigning a dot separated field of the record, creating missing parents
+func RecordPath(path string) string {
+	segments := strings.Split(path, ".")
+	expr := "record"
+	for _, s := range segments[:len(segments)-1] {
+		expr = fmt.Sprintf("(%s[%s] ||= {})", expr, RubyString(s))
+	}
+	return fmt.Sprintf("%s[%s]", expr, RubyString(segments[len(segments)-1]))
+}
@@ -1,10 +1,15 @@
 package fluentd
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	. "github.com/openshift/cluster-logging-operator/internal/generator"
 	. "github.com/openshift/cluster-logging-operator/internal/generator/fluentd/elements"
 	"github.com/openshift/cluster-logging-operator/internal/generator/fluentd/helpers"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 )
 
 func Concat(spec *logging.ClusterLogForwarderSpec, o Options) []Element {
@@ -40,6 +45,7 @@ func Ingress(spec *logging.ClusterLogForwarderSpec, o Options) []Element {
 					TemplateName: "filterJournalPRIORITY",
 					TemplateStr:  FilterJournalPRIORITY,
 				},
+				FilterJournalUnits(spec),
 				ConfLiteral{
 					Desc:         "Process OVN logs",
 					TemplateName: "processOVNLogs",
@@ -56,11 +62,9 @@ func Ingress(spec *logging.ClusterLogForwarderSpec, o Options) []Element {
 					TemplateName: "retagJournal",
 					TemplateStr:  RetagJournalLogs,
 				},
-				ConfLiteral{
-					Desc:         "Invoke kubernetes apiserver to get kubernetes metadata",
-					TemplateName: "kubernetesMetadata",
-					TemplateStr:  KubernetesMetadataPlugin,
-				},
+				NewKubernetesMetadata(spec),
+				ParseAnnotatedMessage(spec),
+				DetectLogLevel(spec),
 				ConfLiteral{
 					Desc:         "Parse Json fields for container, journal and eventrouter logs",
 					TemplateName: "parseJsonFields",
@@ -87,6 +91,40 @@ func Ingress(spec *logging.ClusterLogForwarderSpec, o Options) []Element {
 	}
 }
 
+// AnnotationMatch returns the patterns of the pod annotations added to the kubernetes metadata of records
+func AnnotationMatch(spec *logging.ClusterLogForwarderSpec) string {
+	patterns := []string{`"^containerType\.logging\.openshift\.io\/.*$"`}
+	if spec.AllowParserAnnotations {
+		patterns = append(patterns, fmt.Sprintf(`"^%s.*$"`, strings.ReplaceAll(regexp.QuoteMeta(logging.ParserAnnotationPrefix), "/", `\/`)))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(patterns, ", "))
+}
+
+// ParseAnnotatedMessage returns the filter parsing the message of container records into the structured field
+// using the parser declared by the pod annotation of the container, logfmt is not supported.
+func ParseAnnotatedMessage(spec *logging.ClusterLogForwarderSpec) Element {
+	if !spec.AllowParserAnnotations {
+		return Nil
+	}
+	parse := fmt.Sprintf(`${begin; case (record.dig('kubernetes', 'annotations') || {})['%s' + record.dig('kubernetes', 'container_name').to_s]; `+
+		`when 'json'; parsed = JSON.parse(record['message']); record['structured'] = parsed if parsed.is_a?(Hash); `+
+		`when 'klog'; parsed = record['message'].match(/%s/); record['structured'] = parsed.named_captures if parsed; `+
+		`end; rescue; end; nil}`, logging.ParserAnnotationPrefix, genhelper.KlogPattern)
+	return Filter{
+		Desc:      "Parse container log messages in the format declared by pod annotations",
+		MatchTags: "kubernetes.**",
+		Element: RecordModifier{
+			Records: []Record{
+				{
+					Key:        "_dummy_",
+					Expression: parse,
+				},
+			},
+			RemoveKeys: []string{"_dummy_"},
+		},
+	}
+}
+
 const FilterJournalPRIORITY string = `
 {{define "filterJournalPRIORITY" -}}
 # {{.Desc}}
@@ -100,6 +138,37 @@ const FilterJournalPRIORITY string = `
 {{end}}
 `
 
+// FilterJournalUnits returns the filter dropping the journal records of the units excluded by the infrastructure inputs
+func FilterJournalUnits(spec *logging.ClusterLogForwarderSpec) Element {
+	filter := genhelper.InfrastructureJournal(spec)
+	if filter == nil || len(filter.ExcludeUnits) == 0 {
+		return Nil
+	}
+	units := []string{}
+	for _, unit := range genhelper.JournalUnits(filter.ExcludeUnits) {
+		units = append(units, regexp.QuoteMeta(unit))
+	}
+	return ConfLiteral{
+		Desc:         "Filter out journal logs of excluded units",
+		TemplateName: "filterJournalUnits",
+		TemplateStr:  FilterJournalUnitsTemplate,
+		Pattern:      fmt.Sprintf("^(%s)$", strings.Join(units, "|")),
+	}
+}
+
+const FilterJournalUnitsTemplate string = `
+{{define "filterJournalUnits" -}}
+# {{.Desc}}
+<filter journal>
+  @type grep
+  <exclude>
+    key _SYSTEMD_UNIT
+    pattern /{{.Pattern}}/
+  </exclude>
+</filter>
+{{end}}
+`
+
 const ProcessOVNLogs string = `
 {{define "processOVNLogs" -}}
 # {{.Desc}}
@@ -108,7 +177,9 @@ const ProcessOVNLogs string = `
   <record>
     @timestamp ${DateTime.parse(record['message'].split('|')[0]).rfc3339(6)}
     level ${record['message'].split('|')[3].downcase}
+    _dummy_ ${m = record['message'].to_s.match(/\|acl_log\([^)]*\)\|[^|]*\|name="?(?<name>[^",]*)"?, verdict=(?<verdict>[^,:]+)(?:, severity=(?<severity>[^,:]+))?(?:, direction=(?<direction>[^,:]+))?(?:: (?<flow>.*))?$/); if m; flow = m['flow'].to_s.split(','); fields = flow.map { |f| f.split('=', 2) }.select { |f| f.size == 2 }.to_h; record['ovn'] = {'acl_name' => m['name'], 'verdict' => m['verdict'], 'severity' => m['severity'], 'direction' => m['direction'], 'protocol' => flow[0], 'src_ip' => fields['nw_src'] || fields['ipv6_src'], 'dst_ip' => fields['nw_dst'] || fields['ipv6_dst'], 'src_port' => (fields['tp_src'].to_i if fields['tp_src']), 'dst_port' => (fields['tp_dst'].to_i if fields['tp_dst'])}.compact; end; nil}
   </record>
+  remove_keys _dummy_
 </filter>
 {{end}}
 `
@@ -200,21 +271,6 @@ const RetagJournalLogs string = `
 {{end}}
 `
 
-const KubernetesMetadataPlugin string = `
-{{define "kubernetesMetadata" -}}
-# {{.Desc}}
-<filter kubernetes.**>
-  @id kubernetes-metadata
-  @type kubernetes_metadata
-  kubernetes_url 'https://kubernetes.default.svc'
-  annotation_match ["^containerType\.logging\.openshift\.io\/.*$"]
-  allow_orphans false
-  cache_size '1000'
-  ssl_partial_chain 'true'
-</filter>
-{{end}}
-`
-
 const ParseJsonFields string = `
 {{define "parseJsonFields" -}}
 # {{.Desc}}
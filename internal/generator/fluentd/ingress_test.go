@@ -0,0 +1,201 @@
+package fluentd
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+	"github.com/openshift/cluster-logging-operator/internal/utils"
+	"github.com/openshift/cluster-logging-operator/test/helpers"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Ingress parser annotations", func() {
+	var f = func(clspec logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
+		return []generator.Element{ParseAnnotatedMessage(&clfspec)}
+	}
+	DescribeTable("#ParseAnnotatedMessage", helpers.TestGenerateConfWith(f),
+		Entry("when parser annotations are denied", helpers.ConfGenerateTest{
+			CLFSpec:      logging.ClusterLogForwarderSpec{},
+			ExpectedConf: ``,
+		}),
+		Entry("when parser annotations are allowed", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				AllowParserAnnotations: true,
+			},
+			ExpectedConf: `
+#Parse container log messages in the format declared by pod annotations
+<filter kubernetes.**>
+  @type record_modifier
+  <record>
+    _dummy_ ${begin; case (record.dig('kubernetes', 'annotations') || {})['logging.openshift.io/parser.' + record.dig('kubernetes', 'container_name').to_s]; when 'json'; parsed = JSON.parse(record['message']); record['structured'] = parsed if parsed.is_a?(Hash); when 'klog'; parsed = record['message'].match(/^(?<level>[IWEF])(?<timestamp>\d{4} \d{2}:\d{2}:\d{2}\.\d+)\s+(?<id>\d+) (?<file>[^:]+):(?<line>\d+)\] (?<message>.*)$/); record['structured'] = parsed.named_captures if parsed; end; rescue; end; nil}
+  </record>
+  remove_keys _dummy_
+</filter>
+`,
+		}),
+	)
+
+	It("should keep the parser annotations in the kubernetes metadata when allowed", func() {
+		Expect(AnnotationMatch(&logging.ClusterLogForwarderSpec{})).To(Equal(`["^containerType\.logging\.openshift\.io\/.*$"]`))
+		Expect(AnnotationMatch(&logging.ClusterLogForwarderSpec{AllowParserAnnotations: true})).To(Equal(`["^containerType\.logging\.openshift\.io\/.*$", "^logging\.openshift\.io\/parser\..*$"]`))
+	})
+})
+
+var _ = Describe("Ingress level detection", func() {
+	var f = func(clspec logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
+		return []generator.Element{DetectLogLevel(&clfspec)}
+	}
+	DescribeTable("#DetectLogLevel", helpers.TestGenerateConfWith(f),
+		Entry("with the default level detection", helpers.ConfGenerateTest{
+			CLFSpec:      logging.ClusterLogForwarderSpec{},
+			ExpectedConf: ``,
+		}),
+		Entry("with level detection rules", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				LevelDetection: &logging.LevelDetectionSpec{
+					Rules: []logging.LevelDetectionRule{
+						{Field: "structured.severity"},
+						{Pattern: `^(?P<level>[IWEF])\d{4} `},
+						{Pattern: `\bpanic:`, Level: "critical"},
+					},
+					Mapping: map[string]string{
+						"W": "warn",
+						"e": "error",
+					},
+				},
+			},
+			ExpectedConf: `
+#Detect the level of container logs
+<filter kubernetes.**>
+  @type record_modifier
+  <record>
+    _dummy_ ${if !record.key?('level'); level = nil; level ||= record.dig('structured', 'severity'); level ||= (m = record['message'].to_s.match(/^(?<level>[IWEF])\d{4} /)) && m['level']; level ||= 'critical' if record['message'].to_s.match?(/\bpanic:/); level = level.to_s.downcase; level = 'default' if level.empty?; level = {'e' => 'error', 'w' => 'warn'}.fetch(level, level); record['level'] = level; end; nil}
+  </record>
+  remove_keys _dummy_
+</filter>
+`,
+		}),
+		Entry("with level detection rules quoting user values", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				LevelDetection: &logging.LevelDetectionSpec{
+					Rules: []logging.LevelDetectionRule{
+						{Field: `it's.level\`},
+						{Pattern: `#{exit}/`, Level: "o'k"},
+					},
+					Mapping: map[string]string{
+						"o'k": `info\`,
+					},
+				},
+			},
+			ExpectedConf: `#Detect the level of container logs
+<filter kubernetes.**>
+  @type record_modifier
+  <record>
+    _dummy_ ${if !record.key?('level'); level = nil; level ||= record.dig('it\'s', 'level\\'); level ||= 'o\'k' if record['message'].to_s.match?(/\#{exit}\//); level = level.to_s.downcase; level = 'default' if level.empty?; level = {'o\'k' => 'info\\'}.fetch(level, level); record['level'] = level; end; nil}
+  </record>
+  remove_keys _dummy_
+</filter>`,
+		}),
+	)
+})
+
+var _ = Describe("Ingress kubernetes metadata enrichment", func() {
+	var f = func(clspec logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
+		return []generator.Element{NewKubernetesMetadata(&clfspec)}
+	}
+	DescribeTable("#NewKubernetesMetadata", helpers.TestGenerateConfWith(f),
+		Entry("without enrichment", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{},
+			ExpectedConf: `
+# Invoke kubernetes apiserver to get kubernetes metadata
+<filter kubernetes.**>
+  @id kubernetes-metadata
+  @type kubernetes_metadata
+  kubernetes_url 'https://kubernetes.default.svc'
+  annotation_match ["^containerType\.logging\.openshift\.io\/.*$"]
+  allow_orphans false
+  cache_size '1000'
+  ssl_partial_chain 'true'
+</filter>
+`,
+		}),
+		Entry("with enrichment leaving namespace labels unset", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Enrichment: &logging.EnrichmentSpec{},
+			},
+			ExpectedConf: `
+# Invoke kubernetes apiserver to get kubernetes metadata
+<filter kubernetes.**>
+  @id kubernetes-metadata
+  @type kubernetes_metadata
+  kubernetes_url 'https://kubernetes.default.svc'
+  annotation_match ["^containerType\.logging\.openshift\.io\/.*$"]
+  allow_orphans false
+  cache_size '1000'
+  ssl_partial_chain 'true'
+</filter>
+`,
+		}),
+		Entry("with enrichment opting out of namespace labels", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Enrichment: &logging.EnrichmentSpec{NamespaceLabels: utils.GetBool(false)},
+			},
+			ExpectedConf: `
+# Invoke kubernetes apiserver to get kubernetes metadata
+<filter kubernetes.**>
+  @id kubernetes-metadata
+  @type kubernetes_metadata
+  kubernetes_url 'https://kubernetes.default.svc'
+  annotation_match ["^containerType\.logging\.openshift\.io\/.*$"]
+  allow_orphans false
+  cache_size '1000'
+  ssl_partial_chain 'true'
+  skip_namespace_metadata true
+</filter>
+`,
+		}),
+	)
+})
+
+var _ = Describe("Ingress journal filtering", func() {
+	var f = func(clspec logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
+		return []generator.Element{FilterJournalUnits(&clfspec)}
+	}
+	DescribeTable("#FilterJournalUnits", helpers.TestGenerateConfWith(f),
+		Entry("when the whole journal is collected", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{InputRefs: []string{logging.InputNameInfrastructure}, OutputRefs: []string{logging.OutputNameDefault}},
+				},
+			},
+			ExpectedConf: ``,
+		}),
+		Entry("when units are excluded", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "nodes",
+						Infrastructure: &logging.Infrastructure{
+							Journal: &logging.Journal{ExcludeUnits: []string{"crio", "kubelet.service"}},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{InputRefs: []string{"nodes"}, OutputRefs: []string{logging.OutputNameDefault}},
+				},
+			},
+			ExpectedConf: `
+# Filter out journal logs of excluded units
+<filter journal>
+  @type grep
+  <exclude>
+    key _SYSTEMD_UNIT
+    pattern /^(crio\.service|kubelet\.service)$/
+  </exclude>
+</filter>
+`,
+		}),
+	)
+})
@@ -0,0 +1,63 @@
+package fluentd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	. "github.com/openshift/cluster-logging-operator/internal/generator"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/fluentd/elements"
+	"github.com/openshift/cluster-logging-operator/internal/generator/fluentd/helpers"
+)
+
+// DetectLogLevel returns the filter setting the level of container records without one using the rules
+// of the level detection. The viaq data model detects the level by default.
+func DetectLogLevel(spec *logging.ClusterLogForwarderSpec) Element {
+	if spec.LevelDetection == nil {
+		return Nil
+	}
+	ruby := []string{"if !record.key?('level')", "level = nil"}
+	for _, rule := range spec.LevelDetection.Rules {
+		switch {
+		case rule.Field != "":
+			ruby = append(ruby, fmt.Sprintf("level ||= record.dig(%s)", rubyStrings(strings.Split(rule.Field, "."))))
+		case rule.Level != "":
+			ruby = append(ruby, fmt.Sprintf("level ||= %s if record['message'].to_s.match?(/%s/)", helpers.RubyString(rule.Level), RegexpLiteral(rule.Pattern)))
+		default:
+			ruby = append(ruby, fmt.Sprintf("level ||= (m = record['message'].to_s.match(/%s/)) && m['level']", RegexpLiteral(rule.Pattern)))
+		}
+	}
+	ruby = append(ruby, "level = level.to_s.downcase", "level = 'default' if level.empty?")
+	if len(spec.LevelDetection.Mapping) != 0 {
+		mapping := []string{}
+		for from, to := range spec.LevelDetection.Mapping {
+			mapping = append(mapping, fmt.Sprintf("%s => %s", helpers.RubyString(strings.ToLower(from)), helpers.RubyString(to)))
+		}
+		sort.Strings(mapping)
+		ruby = append(ruby, fmt.Sprintf("level = {%s}.fetch(level, level)", strings.Join(mapping, ", ")))
+	}
+	ruby = append(ruby, "record['level'] = level", "end", "nil")
+	return Filter{
+		Desc:      "Detect the level of container logs",
+		MatchTags: "kubernetes.**",
+		Element: RecordModifier{
+			Records: []Record{
+				{
+					Key:        "_dummy_",
+					Expression: fmt.Sprintf("${%s}", strings.Join(ruby, "; ")),
+				},
+			},
+			RemoveKeys: []string{"_dummy_"},
+		},
+	}
+}
+
+// rubyStrings returns the comma separated ruby literals of strings
+func rubyStrings(values []string) string {
+	literals := make([]string, len(values))
+	for i, v := range values {
+		literals[i] = helpers.RubyString(v)
+	}
+	return strings.Join(literals, ", ")
+}
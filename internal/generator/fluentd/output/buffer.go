@@ -47,7 +47,7 @@ func MakeBuffer(bufkeys []string, bufspec *logging.FluentdBufferSpec, bufpath st
 	return BufferConfData{
 		BufferPath:       BufferPath(bufpath),
 		FlushMode:        Optional("flush_mode", FlushMode(bufspec)),
-		FlushThreadCount: Optional("flush_thread_count", FlushThreadCount(bufspec)),
+		FlushThreadCount: Optional("flush_thread_count", FlushThreadCount(os, bufspec)),
 		FlushInterval: func(os *logging.OutputSpec, bufspec *logging.FluentdBufferSpec) Element {
 			if FlushMode(bufspec) != flushModeInterval {
 				return Nil
@@ -55,12 +55,13 @@ func MakeBuffer(bufkeys []string, bufspec *logging.FluentdBufferSpec, bufpath st
 			return Optional("flush_interval", FlushInterval(os, bufspec))
 		}(os, bufspec),
 		RetryType:            Optional("retry_type", RetryType(bufspec)),
-		RetryWait:            Optional("retry_wait", RetryWait(bufspec)),
-		RetryMaxInterval:     Optional("retry_max_interval", RetryMaxInterval(bufspec)),
+		RetryWait:            Optional("retry_wait", RetryWait(os, bufspec)),
+		RetryMaxInterval:     Optional("retry_max_interval", RetryMaxInterval(os, bufspec)),
 		RetryTimeout:         Optional("retry_timeout", RetryTimeout(bufspec)),
 		QueuedChunkLimitSize: Optional("queued_chunks_limit_size", QueuedChunkLimitSize(bufspec)),
 		TotalLimitSize:       Optional("total_limit_size", TotalLimitSize(bufspec)),
-		ChunkLimitSize:       Optional("chunk_limit_size", ChunkLimitSize(bufspec)),
+		ChunkLimitSize:       Optional("chunk_limit_size", ChunkLimitSize(os, bufspec)),
+		ChunkLimitRecords:    ChunkLimitRecords(os),
 		OverflowAction:       Optional("overflow_action", OverflowAction(os, bufspec)),
 	}
 }
@@ -69,13 +70,24 @@ func BufferPath(bufpath string) string {
 	return fmt.Sprintf("/var/lib/fluentd/%s", bufpath)
 }
 
-func ChunkLimitSize(bufspec *logging.FluentdBufferSpec) string {
+func ChunkLimitSize(os *logging.OutputSpec, bufspec *logging.FluentdBufferSpec) string {
+	if tuning := outputTuning(os); tuning != nil && tuning.MaxBatchBytes > 0 {
+		return fmt.Sprintf("%d", tuning.MaxBatchBytes)
+	}
 	if bufspec != nil && bufspec.ChunkLimitSize != "" {
 		return string(bufspec.ChunkLimitSize)
 	}
 	return FromEnv("BUFFER_SIZE_LIMIT", defaultBufferSizeLimit)
 }
 
+// ChunkLimitRecords limits the number of records of a chunk if tuned for the output
+func ChunkLimitRecords(os *logging.OutputSpec) Element {
+	if tuning := outputTuning(os); tuning != nil && tuning.MaxBatchEvents > 0 {
+		return Optional("chunk_limit_records", fmt.Sprintf("%d", tuning.MaxBatchEvents))
+	}
+	return Nil
+}
+
 func QueuedChunkLimitSize(bufspec *logging.FluentdBufferSpec) string {
 	return FromEnv("BUFFER_QUEUE_LIMIT", defaultBufferQueueLimit)
 }
@@ -104,7 +116,10 @@ func OverflowAction(os *logging.OutputSpec, bufspec *logging.FluentdBufferSpec)
 	}
 }
 
-func FlushThreadCount(bufspec *logging.FluentdBufferSpec) string {
+func FlushThreadCount(os *logging.OutputSpec, bufspec *logging.FluentdBufferSpec) string {
+	if tuning := outputTuning(os); tuning != nil && tuning.Concurrency > 0 {
+		return fmt.Sprintf("%d", tuning.Concurrency)
+	}
 	if bufspec != nil {
 		ftc := bufspec.FlushThreadCount
 
@@ -127,6 +142,9 @@ func FlushMode(bufspec *logging.FluentdBufferSpec) string {
 }
 
 func FlushInterval(os *logging.OutputSpec, bufspec *logging.FluentdBufferSpec) string {
+	if tuning := outputTuning(os); tuning != nil && tuning.BatchTimeout > 0 {
+		return fmt.Sprintf("%ds", tuning.BatchTimeout)
+	}
 	if bufspec != nil {
 		fi := string(bufspec.FlushInterval)
 
@@ -143,7 +161,10 @@ func FlushInterval(os *logging.OutputSpec, bufspec *logging.FluentdBufferSpec) s
 	}
 }
 
-func RetryWait(bufspec *logging.FluentdBufferSpec) string {
+func RetryWait(os *logging.OutputSpec, bufspec *logging.FluentdBufferSpec) string {
+	if tuning := outputTuning(os); tuning != nil && tuning.MinRetryDuration > 0 {
+		return fmt.Sprintf("%ds", tuning.MinRetryDuration)
+	}
 	if bufspec != nil {
 		rw := string(bufspec.RetryWait)
 
@@ -165,7 +186,10 @@ func RetryType(bufspec *logging.FluentdBufferSpec) string {
 	return defaultRetryType
 }
 
-func RetryMaxInterval(bufspec *logging.FluentdBufferSpec) string {
+func RetryMaxInterval(os *logging.OutputSpec, bufspec *logging.FluentdBufferSpec) string {
+	if tuning := outputTuning(os); tuning != nil && tuning.MaxRetryDuration > 0 {
+		return fmt.Sprintf("%ds", tuning.MaxRetryDuration)
+	}
 	if bufspec != nil {
 		rmi := string(bufspec.RetryMaxInterval)
 
@@ -186,6 +210,14 @@ func RetryTimeout(bufspec *logging.FluentdBufferSpec) string {
 	return value
 }
 
+// outputTuning returns the tuning of an output which takes precedence over the forwarder buffer spec
+func outputTuning(os *logging.OutputSpec) *logging.OutputTuningSpec {
+	if os == nil {
+		return nil
+	}
+	return os.Tuning
+}
+
 func FromEnv(env string, defaultVal string) string {
 	return fmt.Sprintf("\"#{ENV['%s'] || '%s'}\"", env, defaultVal)
 }
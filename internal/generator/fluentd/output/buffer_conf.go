@@ -38,6 +38,7 @@ type BufferConfData struct {
 	QueuedChunkLimitSize Element
 	TotalLimitSize       Element
 	ChunkLimitSize       Element
+	ChunkLimitRecords    Element
 	OverflowAction       Element
 }
 
@@ -59,6 +60,7 @@ path '{{.BufferPath}}'
 {{optional .QueuedChunkLimitSize -}}
 {{optional .TotalLimitSize -}}
 {{optional .ChunkLimitSize -}}
+{{optional .ChunkLimitRecords -}}
 {{optional .OverflowAction -}}
 {{end}}
 `
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	. "github.com/openshift/cluster-logging-operator/internal/generator"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/fluentd/elements"
+	"github.com/openshift/cluster-logging-operator/internal/generator/fluentd/helpers"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+)
+
+// DataModel returns the filter translating records from the ViaQ data model to the data model of the output
+func DataModel(o logging.OutputSpec) Element {
+	if !genhelper.HasDataModel(o) {
+		return Nil
+	}
+	ruby := []string{}
+	for _, m := range genhelper.DataModelMoves(o) {
+		from := strings.Split(m.From, ".")
+		field := from[len(from)-1]
+		parent := "record"
+		exists := fmt.Sprintf("record.key?('%s')", field)
+		if len(from) > 1 {
+			parent = "p"
+			exists = fmt.Sprintf("(p = record.dig('%s')).is_a?(Hash) && p.key?('%s')", strings.Join(from[:len(from)-1], "', '"), field)
+		}
+		ruby = append(ruby, fmt.Sprintf("if %s; %s = %s.delete('%s'); end", exists, helpers.RecordPath(m.To), parent, field))
+	}
+	for _, v := range genhelper.DataModelValues(o) {
+		ruby = append(ruby, fmt.Sprintf("%s = %s", helpers.RecordPath(v.Path), helpers.RubyString(v.Value)))
+	}
+	ruby = append(ruby, "nil")
+	return Filter{
+		Desc:      fmt.Sprintf("Translate records to the %s data model", o.DataModel),
+		MatchTags: "**",
+		Element: RecordModifier{
+			Records: []Record{
+				{
+					Key:        "_dummy_",
+					Expression: fmt.Sprintf("${%s}", strings.Join(ruby, "; ")),
+				},
+			},
+			RemoveKeys: []string{"_dummy_"},
+		},
+	}
+}
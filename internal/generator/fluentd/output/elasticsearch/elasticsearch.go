@@ -1,6 +1,9 @@
 package elasticsearch
 
 import (
+	"fmt"
+	"strings"
+
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	"github.com/openshift/cluster-logging-operator/internal/constants"
 	corev1 "k8s.io/api/core/v1"
@@ -24,7 +27,10 @@ type Elasticsearch struct {
 	StoreID        string
 	Host           string
 	Port           string
+	Hosts          string
 	RetryTag       Element
+	Compression    Element
+	RequestTimeout string
 	SecurityConfig []Element
 	BufferConfig   []Element
 }
@@ -40,8 +46,12 @@ func (e Elasticsearch) Template() string {
 {{ end -}}
 @type elasticsearch
 @id {{.StoreID}}
+{{if .Hosts -}}
+hosts {{.Hosts}}
+{{else -}}
 host {{.Host}}
 port {{.Port}}
+{{end -}}
 verify_es_version_at_startup false
 {{compose .SecurityConfig}}
 target_index_key viaq_index_name
@@ -59,8 +69,13 @@ reload_after '200'
 # https://github.com/uken/fluent-plugin-elasticsearch#sniffer-class-name
 sniffer_class_name 'Fluent::Plugin::ElasticsearchSimpleSniffer'
 reload_on_failure false
+{{kv .Compression -}}
+{{if .RequestTimeout -}}
+request_timeout {{.RequestTimeout}}
+{{else -}}
 # 2 ^ 31
 request_timeout 2147483648
+{{end -}}
 {{compose .BufferConfig}}
 {{- end}}
 `
@@ -72,6 +87,7 @@ func Conf(bufspec *logging.FluentdBufferSpec, secret *corev1.Secret, o logging.O
 			InLabel: helpers.LabelName(o.Name),
 			SubElements: MergeElements(
 				ViaqDataModel(bufspec, secret, o, op),
+				[]Element{output.DataModel(o)},
 				OutputConf(bufspec, secret, o, op),
 			),
 		},
@@ -111,11 +127,49 @@ func Output(bufspec *logging.FluentdBufferSpec, secret *corev1.Secret, o logging
 		StoreID:        storeID,
 		Host:           u.Hostname(),
 		Port:           port,
+		Hosts:          Hosts(o),
+		Compression:    Compression(o),
+		RequestTimeout: RequestTimeout(o),
 		SecurityConfig: SecurityConfig(o, secret),
 		BufferConfig:   output.Buffer(output.NOKEYS, bufspec, storeID, &o),
 	}
 }
 
+// Hosts returns the comma separated hosts of an output with several URLs, the client distributes
+// requests across them and skips unreachable hosts
+func Hosts(o logging.OutputSpec) string {
+	if len(o.URLs) <= 1 {
+		return ""
+	}
+	hosts := []string{}
+	for _, s := range o.URLs {
+		// URL is parasable, checked at input sanitization
+		u, _ := url.Parse(s)
+		port := u.Port()
+		if port == "" {
+			port = defaultElasticsearchPort
+		}
+		hosts = append(hosts, fmt.Sprintf("%s:%s", u.Hostname(), port))
+	}
+	return strings.Join(hosts, ",")
+}
+
+// Compression enables gzip compression of bulk requests if tuned for the output
+func Compression(o logging.OutputSpec) Element {
+	if o.Tuning != nil && o.Tuning.Compression == "gzip" {
+		return KV("compression_level", "default_compression")
+	}
+	return Nil
+}
+
+// RequestTimeout returns the tuned request timeout of the output, empty for the default
+func RequestTimeout(o logging.OutputSpec) string {
+	if o.Tuning != nil && o.Tuning.RequestTimeout > 0 {
+		return fmt.Sprintf("%ds", o.Tuning.RequestTimeout)
+	}
+	return ""
+}
+
 func RetryOutput(bufspec *logging.FluentdBufferSpec, secret *corev1.Secret, o logging.OutputSpec, op Options) Elasticsearch {
 	es := Output(bufspec, secret, o, op)
 	es.StoreID = helpers.StoreID("retry_", o.Name, "")
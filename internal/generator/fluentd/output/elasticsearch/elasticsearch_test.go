@@ -3,6 +3,7 @@ package elasticsearch
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	"github.com/openshift/cluster-logging-operator/internal/generator"
 	"github.com/openshift/cluster-logging-operator/internal/generator/fluentd/elements"
@@ -1212,3 +1213,13 @@ var _ = Describe("Generate fluentd config", func() {
 		}),
 	)
 })
+
+var _ = Describe("Elasticsearch hosts", func() {
+	DescribeTable("#Hosts", func(urls []string, hosts string) {
+		Expect(Hosts(logging.OutputSpec{URLs: urls})).To(Equal(hosts))
+	},
+		Entry("without urls", nil, ""),
+		Entry("with a single url", []string{"https://es.svc:9200"}, ""),
+		Entry("with several urls", []string{"https://es-0.svc:9200", "https://es-1.svc"}, "es-0.svc:9200,es-1.svc:9200"),
+	)
+})
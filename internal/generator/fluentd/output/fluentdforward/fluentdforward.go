@@ -22,8 +22,8 @@ const (
 type FluentdForward struct {
 	Desc           string
 	StoreID        string
-	Host           string
-	Port           string
+	Servers        []Server
+	Compress       generator.Element
 	BufferConfig   []generator.Element
 	SecurityConfig []generator.Element
 }
@@ -39,13 +39,19 @@ func (ff FluentdForward) Template() string {
 {{end -}}
 @type forward
 @id {{.StoreID}}
+{{range .Servers -}}
 <server>
   host {{.Host}}
   port {{.Port}}
+{{- if .Standby}}
+  standby true
+{{- end}}
 </server>
+{{end -}}
 heartbeat_type none
 keepalive true
 keepalive_timeout 30s
+{{kv .Compress -}}
 {{compose .SecurityConfig}}
 {{compose .BufferConfig}}
 {{- end}}
@@ -57,6 +63,7 @@ func Conf(bufspec *logging.FluentdBufferSpec, secret *corev1.Secret, o logging.O
 		elements.FromLabel{
 			InLabel: helpers.LabelName(o.Name),
 			SubElements: []generator.Element{
+				output.DataModel(o),
 				Output(bufspec, secret, o, op),
 			},
 		},
@@ -67,25 +74,54 @@ func Output(bufspec *logging.FluentdBufferSpec, secret *corev1.Secret, o logging
 	if genhelper.IsDebugOutput(op) {
 		return genhelper.DebugOutput
 	}
-	// URL is parasable, checked at input sanitization
-	u, _ := url.Parse(o.URL)
-	port := u.Port()
-	if port == "" {
-		port = defaultFluentdForwardPort
-	}
 	storeID := strings.ToLower(helpers.Replacer.Replace(o.Name))
 	return elements.Match{
 		MatchTags: "**",
 		MatchElement: FluentdForward{
 			StoreID:        storeID,
-			Host:           u.Hostname(),
-			Port:           port,
+			Servers:        Servers(o),
+			Compress:       Compress(o),
 			SecurityConfig: SecurityConfig(o, secret),
 			BufferConfig:   output.Buffer(output.NOKEYS, bufspec, storeID, &o),
 		},
 	}
 }
 
+// Server is a fluentd instance receiving the forwarded records
+type Server struct {
+	Host    string
+	Port    string
+	Standby bool
+}
+
+// Servers returns a server for each URL of the output. The failover strategy
+// puts all servers but the first on standby until the first is unavailable.
+func Servers(o logging.OutputSpec) []Server {
+	servers := []Server{}
+	for i, s := range genhelper.OutputURLs(o) {
+		// URL is parasable, checked at input sanitization
+		u, _ := url.Parse(s)
+		port := u.Port()
+		if port == "" {
+			port = defaultFluentdForwardPort
+		}
+		servers = append(servers, Server{
+			Host:    u.Hostname(),
+			Port:    port,
+			Standby: i > 0 && o.Strategy == logging.OutputURLStrategyFailover,
+		})
+	}
+	return servers
+}
+
+// Compress enables gzip compression of the forwarded chunks if tuned for the output
+func Compress(o logging.OutputSpec) generator.Element {
+	if o.Tuning != nil && o.Tuning.Compression == "gzip" {
+		return elements.KV("compress", "gzip")
+	}
+	return generator.Nil
+}
+
 func SecurityConfig(o logging.OutputSpec, secret *corev1.Secret) []generator.Element {
 	// URL is parasable, checked at input sanitization
 	u, _ := url.Parse(o.URL)
@@ -191,6 +191,57 @@ var _ = Describe("fluentd conf generation", func() {
     </buffer>
   </match>
 </label>
+`,
+		}),
+		Entry("with failover urls", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Outputs: []logging.OutputSpec{
+					{
+						Type:     logging.OutputTypeFluentdForward,
+						Name:     "forward-receiver",
+						URL:      "tcp://fluentd-0.svc.messaging.cluster.local:24224",
+						URLs:     []string{"tcp://fluentd-0.svc.messaging.cluster.local:24224", "tcp://fluentd-1.svc.messaging.cluster.local"},
+						Strategy: logging.OutputURLStrategyFailover,
+					},
+				},
+			},
+			Secrets: security.NoSecrets,
+			ExpectedConf: `
+<label @FORWARD_RECEIVER>
+  <match **>
+    @type forward
+    @id forward_receiver
+    <server>
+      host fluentd-0.svc.messaging.cluster.local
+      port 24224
+    </server>
+    <server>
+      host fluentd-1.svc.messaging.cluster.local
+      port 24224
+      standby true
+    </server>
+    heartbeat_type none
+    keepalive true
+    keepalive_timeout 30s
+
+    <buffer>
+      @type file
+      path '/var/lib/fluentd/forward_receiver'
+      flush_mode interval
+      flush_interval 5s
+      flush_thread_count 2
+      retry_type exponential_backoff
+      retry_wait 1s
+      retry_max_interval 60s
+      retry_timeout 60m
+      queued_chunks_limit_size "#{ENV['BUFFER_QUEUE_LIMIT'] || '32'}"
+      total_limit_size "#{ENV['TOTAL_LIMIT_SIZE_PER_BUFFER'] || '8589934592'}"
+      chunk_limit_size "#{ENV['BUFFER_SIZE_LIMIT'] || '8m'}"
+      overflow_action block
+      disable_chunk_backup true
+    </buffer>
+  </match>
+</label>
 `,
 		}),
 	)
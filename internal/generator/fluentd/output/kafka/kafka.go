@@ -26,6 +26,7 @@ type Kafka struct {
 	StoreID        string
 	Brokers        string
 	Topics         string
+	Compression    Element
 	SecurityConfig []Element
 	BufferConfig   []Element
 }
@@ -41,6 +42,7 @@ func (k Kafka) Template() string {
 brokers {{.Brokers}}
 default_topic {{.Topics}}
 use_event_time true
+{{kv .Compression -}}
 {{- with $x := compose .SecurityConfig }}
 {{$x}}
 {{- end}}
@@ -57,6 +59,7 @@ func Conf(bufspec *logging.FluentdBufferSpec, secret *corev1.Secret, o logging.O
 		FromLabel{
 			InLabel: helpers.LabelName(o.Name),
 			SubElements: []Element{
+				output.DataModel(o),
 				Output(bufspec, secret, o, op),
 			},
 		},
@@ -75,6 +78,7 @@ func Output(bufspec *logging.FluentdBufferSpec, secret *corev1.Secret, o logging
 			StoreID:        strings.ToLower(helpers.Replacer.Replace(o.Name)),
 			Topics:         topics,
 			Brokers:        Brokers(o),
+			Compression:    Compression(o),
 			SecurityConfig: SecurityConfig(o, secret),
 			BufferConfig:   output.Buffer([]string{topics}, bufspec, storeID, &o),
 		},
@@ -137,6 +141,14 @@ func Topics(o logging.OutputSpec) string {
 	return defaultKafkaTopic
 }
 
+// Compression returns the codec used by the kafka producer if tuned for the output
+func Compression(o logging.OutputSpec) Element {
+	if o.Tuning != nil && o.Tuning.Compression != "" && o.Tuning.Compression != "none" {
+		return KV("compression_codec", o.Tuning.Compression)
+	}
+	return Nil
+}
+
 func SecurityConfig(o logging.OutputSpec, secret *corev1.Secret) []Element {
 	conf := []Element{}
 	if o.Secret != nil {
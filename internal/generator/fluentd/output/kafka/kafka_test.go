@@ -181,6 +181,59 @@ var _ = Describe("Generate fluentd config", func() {
     </buffer>
   </match>
 </label>
+`,
+		}),
+		Entry("with the ECS data model", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Outputs: []logging.OutputSpec{
+					{
+						Type:      logging.OutputTypeKafka,
+						Name:      "kafka-receiver",
+						URL:       "tls://broker1-kafka.svc.messaging.cluster.local:9092/topic",
+						DataModel: logging.DataModelECS,
+					},
+				},
+			},
+			Secrets: security.NoSecrets,
+			ExpectedConf: `
+<label @KAFKA_RECEIVER>
+  #Translate records to the ecs data model
+  <filter **>
+    @type record_modifier
+    <record>
+      _dummy_ ${if record.key?('level'); (record['log'] ||= {})['level'] = record.delete('level'); end; if record.key?('log_type'); (record['event'] ||= {})['dataset'] = record.delete('log_type'); end; if (p = record.dig('openshift')).is_a?(Hash) && p.key?('labels'); record['labels'] = p.delete('labels'); end; if (p = record.dig('openshift')).is_a?(Hash) && p.key?('cluster_id'); ((record['orchestrator'] ||= {})['cluster'] ||= {})['id'] = p.delete('cluster_id'); end; if (p = record.dig('openshift')).is_a?(Hash) && p.key?('cluster_name'); ((record['orchestrator'] ||= {})['cluster'] ||= {})['name'] = p.delete('cluster_name'); end; if record.key?('hostname'); (record['host'] ||= {})['name'] = record.delete('hostname'); end; if (p = record.dig('kubernetes')).is_a?(Hash) && p.key?('namespace_name'); (record['orchestrator'] ||= {})['namespace'] = p.delete('namespace_name'); end; if (p = record.dig('kubernetes')).is_a?(Hash) && p.key?('pod_name'); ((record['orchestrator'] ||= {})['resource'] ||= {})['name'] = p.delete('pod_name'); end; if (p = record.dig('kubernetes')).is_a?(Hash) && p.key?('pod_id'); ((record['orchestrator'] ||= {})['resource'] ||= {})['id'] = p.delete('pod_id'); end; if (p = record.dig('kubernetes')).is_a?(Hash) && p.key?('workload_kind'); (((record['orchestrator'] ||= {})['resource'] ||= {})['parent'] ||= {})['type'] = p.delete('workload_kind'); end; if (p = record.dig('kubernetes')).is_a?(Hash) && p.key?('container_name'); (record['container'] ||= {})['name'] = p.delete('container_name'); end; if (p = record.dig('kubernetes')).is_a?(Hash) && p.key?('container_id'); (record['container'] ||= {})['id'] = p.delete('container_id'); end; if (p = record.dig('kubernetes')).is_a?(Hash) && p.key?('container_image'); ((record['container'] ||= {})['image'] ||= {})['name'] = p.delete('container_image'); end; (record['ecs'] ||= {})['version'] = '8.0.0'; nil}
+    </record>
+    remove_keys _dummy_
+  </filter>
+  
+  <match **>
+    @type kafka2
+    @id kafka_receiver
+    brokers broker1-kafka.svc.messaging.cluster.local:9092
+    default_topic topic
+    use_event_time true
+    
+    <format>
+      @type json
+    </format>
+    <buffer topic>
+      @type file
+      path '/var/lib/fluentd/kafka_receiver'
+      flush_mode interval
+      flush_interval 1s
+      flush_thread_count 2
+      retry_type exponential_backoff
+      retry_wait 1s
+      retry_max_interval 60s
+      retry_timeout 60m
+      queued_chunks_limit_size "#{ENV['BUFFER_QUEUE_LIMIT'] || '32'}"
+      total_limit_size "#{ENV['TOTAL_LIMIT_SIZE_PER_BUFFER'] || '8589934592'}"
+      chunk_limit_size "#{ENV['BUFFER_SIZE_LIMIT'] || '8m'}"
+      overflow_action block
+      disable_chunk_backup true
+    </buffer>
+  </match>
+</label>
 `,
 		}),
 	)
@@ -801,4 +801,63 @@ var _ = Describe("Generating fluentd config", func() {
 			Expect(results).To(EqualTrimLines(kafkaConf))
 		})
 	})
+
+	Context("for output tuning", func() {
+		JustBeforeEach(func() {
+			g = generator.MakeGenerator()
+
+			outputs = []loggingv1.OutputSpec{
+				{
+					Type: loggingv1.OutputTypeKafka,
+					Name: "kafka-receiver",
+					URL:  "tcp://broker1-kafka.svc.messaging.cluster.local:9092/topic",
+					Tuning: &loggingv1.OutputTuningSpec{
+						MaxBatchBytes:    1048576,
+						MaxBatchEvents:   500,
+						MinRetryDuration: 5,
+						MaxRetryDuration: 120,
+						Concurrency:      8,
+						Compression:      "lz4",
+					},
+				},
+			}
+		})
+
+		It("should override the forwarder buffer spec", func() {
+			kafkaConf := `<label @KAFKA_RECEIVER>
+        <match **>
+           @type kafka2
+		   @id kafka_receiver
+           brokers broker1-kafka.svc.messaging.cluster.local:9092
+           default_topic topic
+           use_event_time true
+           compression_codec lz4
+           <format>
+               @type json
+           </format>
+           <buffer topic>
+               @type file
+               path '/var/lib/fluentd/kafka_receiver'
+               flush_mode immediate
+               flush_thread_count 8
+               retry_type periodic
+               retry_wait 5s
+               retry_max_interval 120s
+               retry_timeout 60m
+               queued_chunks_limit_size "#{ENV['BUFFER_QUEUE_LIMIT'] || '32'}"
+               total_limit_size 512m
+               chunk_limit_size 1048576
+               chunk_limit_records 500
+               overflow_action drop_oldest_chunk
+               disable_chunk_backup true
+           </buffer>
+        </match>
+        </label>`
+
+			e := kafka.Conf(customForwarderSpec.Fluentd.Buffer, nil, outputs[0], nil)
+			results, err := g.GenerateConf(e...)
+			Expect(err).To(BeNil())
+			Expect(results).To(EqualTrimLines(kafkaConf))
+		})
+	})
 })
@@ -0,0 +1,97 @@
+package fluentd
+
+import (
+	"fmt"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	. "github.com/openshift/cluster-logging-operator/internal/generator"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+)
+
+// MessageParser parses the message of records into the target field, records which cannot be parsed are
+// left unchanged
+type MessageParser struct {
+	Desc               string
+	TargetField        string
+	RemoveKeyNameField bool
+	Parse              []string
+}
+
+func (p MessageParser) Name() string {
+	return "messageParserTemplate"
+}
+
+func (p MessageParser) Template() string {
+	return `{{define "` + p.Name() + `" -}}
+# {{.Desc}}
+<filter **>
+  @type parser
+  key_name message
+  reserve_data yes
+  hash_value_field {{.TargetField}}
+{{- if .RemoveKeyNameField}}
+  remove_key_name_field true
+{{- end}}
+  emit_invalid_record_to_error false
+  <parse>
+{{- range .Parse}}
+    {{.}}
+{{- end}}
+  </parse>
+</filter>
+<filter **>
+  @type record_modifier
+  remove_keys _dummy_
+  <record>
+    _dummy_ ${record.delete('{{.TargetField}}') if record['{{.TargetField}}'] == {}; nil}
+  </record>
+</filter>
+{{end}}`
+}
+
+// NewMessageParser returns the parser filter of a pipeline parser
+func NewMessageParser(spec *logging.PipelineParserSpec) Element {
+	var parse []string
+	switch spec.Type {
+	case logging.ParserTypeJSON:
+		parse = []string{"@type json", "json_parser oj"}
+	case logging.ParserTypeRegex:
+		parse = []string{"@type regexp", fmt.Sprintf("expression /%s/", RegexpLiteral(spec.Pattern))}
+	case logging.ParserTypeKlog:
+		parse = []string{"@type regexp", fmt.Sprintf("expression /%s/", genhelper.KlogPattern)}
+	case logging.ParserTypeSyslog:
+		parse = []string{"@type syslog", "message_format auto"}
+	case logging.ParserTypeCSV:
+		parse = []string{"@type csv", fmt.Sprintf("keys %s", strings.Join(spec.Headers, ","))}
+	default:
+		return Nil
+	}
+	target := spec.TargetField
+	if target == "" {
+		target = logging.DefaultParserTargetField
+	}
+	return MessageParser{
+		Desc:               fmt.Sprintf("Parse the %s message of logs", spec.Type),
+		TargetField:        target,
+		RemoveKeyNameField: !spec.KeepOriginal,
+		Parse:              parse,
+	}
+}
+
+// RegexpLiteral converts a pattern to the body of a ruby regexp literal,
+// named groups use the (?<name>) syntax of ruby, slashes are escaped and so are
+// hashes to prevent interpolation
+func RegexpLiteral(pattern string) string {
+	pattern = strings.ReplaceAll(pattern, "(?P<", "(?<")
+	literal := strings.Builder{}
+	escaped := false
+	for _, c := range pattern {
+		if (c == '/' || c == '#') && !escaped {
+			literal.WriteRune('\\')
+		}
+		escaped = c == '\\' && !escaped
+		literal.WriteRune(c)
+	}
+	return literal.String()
+}
@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	. "github.com/openshift/cluster-logging-operator/internal/generator"
 	. "github.com/openshift/cluster-logging-operator/internal/generator/fluentd/elements"
 	"github.com/openshift/cluster-logging-operator/internal/generator/fluentd/helpers"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 )
 
 const (
@@ -62,6 +64,9 @@ func PipelineToOutputs(spec *logging.ClusterLogForwarderSpec, op Options) []Elem
 					TemplateStr:  fmt.Sprintf(PipelineLabels, string(s)),
 				})
 		}
+		if fields := genhelper.StaticFields(spec, op); len(fields) != 0 {
+			po.SubElements = append(po.SubElements, StaticFields(fields))
+		}
 		if p.DetectMultilineErrors {
 			po.SubElements = append(po.SubElements,
 				ConfLiteral{
@@ -69,7 +74,9 @@ func PipelineToOutputs(spec *logging.ClusterLogForwarderSpec, op Options) []Elem
 					TemplateStr:  MultilineDetectExceptionTemplate,
 				})
 		}
-		if p.Parse == JSONParseType {
+		if p.Parser != nil {
+			po.SubElements = append(po.SubElements, NewMessageParser(p.Parser))
+		} else if p.Parse == JSONParseType {
 			po.SubElements = append(po.SubElements,
 				ConfLiteral{
 					Desc:         "Parse the logs into json",
@@ -77,6 +84,12 @@ func PipelineToOutputs(spec *logging.ClusterLogForwarderSpec, op Options) []Elem
 					TemplateStr:  JsonParseTemplate,
 				})
 		}
+		if p.StructuredFields != nil {
+			po.SubElements = append(po.SubElements, StructuredFields(p))
+		}
+		if p.Timestamp != nil {
+			po.SubElements = append(po.SubElements, ExtractTimestamp(p.Timestamp))
+		}
 		switch len(p.OutputRefs) {
 		case 0:
 			// should not happen
@@ -102,3 +115,25 @@ func PipelineToOutputs(spec *logging.ClusterLogForwarderSpec, op Options) []Elem
 	}
 	return e
 }
+
+// StaticFields returns the filter stamping the cluster identity and the static fields of the forwarder onto records
+func StaticFields(fields []genhelper.FieldValue) Element {
+	ruby := []string{}
+	for _, f := range fields {
+		ruby = append(ruby, fmt.Sprintf("%s = %s", helpers.RecordPath(f.Path), helpers.RubyString(f.Value)))
+	}
+	ruby = append(ruby, "nil")
+	return Filter{
+		Desc:      "Add static fields to the output record",
+		MatchTags: "**",
+		Element: RecordModifier{
+			Records: []Record{
+				{
+					Key:        "_dummy_",
+					Expression: fmt.Sprintf("${%s}", strings.Join(ruby, "; ")),
+				},
+			},
+			RemoveKeys: []string{"_dummy_"},
+		},
+	}
+}
@@ -11,7 +11,7 @@ import (
 
 var _ = Describe("Testing Config Generation", func() {
 	var f = func(clspec logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
-		return PipelineToOutputs(&clfspec, generator.NoOptions)
+		return PipelineToOutputs(&clfspec, op)
 	}
 	DescribeTable("Pipelines(s) to Output(s)", helpers.TestGenerateConfWith(f),
 		Entry("Application to single output", helpers.ConfGenerateTest{
@@ -121,6 +121,52 @@ var _ = Describe("Testing Config Generation", func() {
       @label @ES_APP_OUT
     </store>
   </match>
+</label>`,
+		}),
+		Entry("Application to default output with Labels, cluster identity and static fields", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "app-to-default",
+						Labels: map[string]string{
+							"a": "b",
+						},
+					},
+				},
+				ClusterIdentity: true,
+				StaticFields: map[string]string{
+					"openshift.region": "eu-west-1",
+					"team":             "o'reilly",
+				},
+			},
+			Options: generator.Options{
+				generator.ClusterID:   "0b5c2ed7-7d31-4c4c-a1a6-7e8e0f8b5a7c",
+				generator.ClusterName: "cluster-1",
+			},
+			ExpectedConf: `
+# Copying pipeline app-to-default to outputs
+<label @APP_TO_DEFAULT>
+  # Add User Defined labels to the output record
+  <filter **>
+    @type record_transformer
+    <record>
+      openshift { "labels": {"a":"b"} }
+    </record>
+  </filter>
+  #Add static fields to the output record
+  <filter **>
+    @type record_modifier
+    <record>
+      _dummy_ ${(record['openshift'] ||= {})['cluster_id'] = '0b5c2ed7-7d31-4c4c-a1a6-7e8e0f8b5a7c'; (record['openshift'] ||= {})['cluster_name'] = 'cluster-1'; (record['openshift'] ||= {})['region'] = 'eu-west-1'; record['team'] = 'o\'reilly'; nil}
+    </record>
+    remove_keys _dummy_
+  </filter>
+  <match **>
+    @type relabel
+    @label @DEFAULT
+  </match>
 </label>`,
 		}),
 		Entry("Application to default output with Json Parsing, and Labels", helpers.ConfGenerateTest{
@@ -174,6 +220,188 @@ var _ = Describe("Testing Config Generation", func() {
       @label @ES_APP_OUT
     </store>
   </match>
+</label>`,
+		}),
+		Entry("Application to output with regex parsing", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication},
+						OutputRefs: []string{"es-app-out"},
+						Name:       "app-to-es",
+						Parser: &logging.PipelineParserSpec{
+							Type:        logging.ParserTypeRegex,
+							Pattern:     `^(?P<method>[A-Z]+) (?P<path>/\S*) (?P<status>\d+)$`,
+							TargetField: "access",
+						},
+					},
+				},
+			},
+			ExpectedConf: `
+# Copying pipeline app-to-es to outputs
+<label @APP_TO_ES>
+  # Parse the regex message of logs
+  <filter **>
+    @type parser
+    key_name message
+    reserve_data yes
+    hash_value_field access
+    remove_key_name_field true
+    emit_invalid_record_to_error false
+    <parse>
+      @type regexp
+      expression /^(?<method>[A-Z]+) (?<path>\/\S*) (?<status>\d+)$/
+    </parse>
+  </filter>
+  <filter **>
+    @type record_modifier
+    remove_keys _dummy_
+    <record>
+      _dummy_ ${record.delete('access') if record['access'] == {}; nil}
+    </record>
+  </filter>
+  
+  <match **>
+    @type relabel
+    @label @ES_APP_OUT
+  </match>
+</label>`,
+		}),
+		Entry("Application to output with structured field coercion and type suffixes", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication},
+						OutputRefs: []string{"es-app-out"},
+						Name:       "app-to-es",
+						Parse:      "json",
+						StructuredFields: &logging.StructuredFieldsSpec{
+							Types: map[string]logging.FieldType{
+								"status":        logging.FieldTypeInteger,
+								"request.bytes": logging.FieldTypeFloat,
+							},
+							Conflicts: logging.StructuredFieldConflictsSuffix,
+						},
+					},
+				},
+			},
+			ExpectedConf: `
+# Copying pipeline app-to-es to outputs
+<label @APP_TO_ES>
+  # Parse the logs into json
+  <filter **>
+    @type parser
+    key_name message
+    reserve_data yes
+    hash_value_field structured
+    <parse>
+      @type json
+      json_parser oj
+    </parse>
+  </filter>
+  
+  #Coerce structured fields and protect them from type conflicts
+  <filter **>
+    @type record_modifier
+    <record>
+      _dummy_ ${s = record['structured']; if s.is_a?(Hash); f = s.dig('request'); if f.is_a?(Hash) && f.key?('bytes'); begin; v = f['bytes']; f['bytes'] = (Float(v)); rescue; f.delete('bytes'); end; end; f = s; if f.is_a?(Hash) && f.key?('status'); begin; v = f['status']; f['status'] = (Integer(v)); rescue; f.delete('status'); end; end; s.keys.each do |k| t = case s[k] when String then 'str' when Numeric then 'num' when true, false then 'bool' end; s[k + '_' + t] = s.delete(k) if !['request', 'status'].include?(k) && t; end; end; nil}
+    </record>
+    remove_keys _dummy_
+  </filter>
+  
+  <match **>
+    @type relabel
+    @label @ES_APP_OUT
+  </match>
+</label>`,
+		}),
+		Entry("Application to output stringifying structured fields", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication},
+						OutputRefs: []string{"es-app-out"},
+						Name:       "app-to-es",
+						Parser: &logging.PipelineParserSpec{
+							Type:        logging.ParserTypeJSON,
+							TargetField: "fields",
+						},
+						StructuredFields: &logging.StructuredFieldsSpec{
+							Conflicts: logging.StructuredFieldConflictsStringify,
+						},
+					},
+				},
+			},
+			ExpectedConf: `
+# Copying pipeline app-to-es to outputs
+<label @APP_TO_ES>
+  # Parse the json message of logs
+  <filter **>
+    @type parser
+    key_name message
+    reserve_data yes
+    hash_value_field fields
+    remove_key_name_field true
+    emit_invalid_record_to_error false
+    <parse>
+      @type json
+      json_parser oj
+    </parse>
+  </filter>
+  <filter **>
+    @type record_modifier
+    remove_keys _dummy_
+    <record>
+      _dummy_ ${record.delete('fields') if record['fields'] == {}; nil}
+    </record>
+  </filter>
+  
+  #Coerce structured fields and protect them from type conflicts
+  <filter **>
+    @type record_modifier
+    <record>
+      _dummy_ ${s = record['fields']; if s.is_a?(Hash); s.keys.each do |k| v = s[k]; s[k] = v.to_s if (v.is_a?(Numeric) || v == true || v == false); end; end; nil}
+    </record>
+    remove_keys _dummy_
+  </filter>
+  
+  <match **>
+    @type relabel
+    @label @ES_APP_OUT
+  </match>
+</label>`,
+		}),
+		Entry("Application to output with timestamp extraction", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication},
+						OutputRefs: []string{"es-app-out"},
+						Name:       "app-to-es",
+						Timestamp: &logging.TimestampSpec{
+							Pattern:  `^\[(?P<timestamp>[^\]]+)\]`,
+							Format:   "%d/%b/%Y:%H:%M:%S",
+							Timezone: "Europe/Paris",
+						},
+					},
+				},
+			},
+			ExpectedConf: `
+# Copying pipeline app-to-es to outputs
+<label @APP_TO_ES>
+  #Extract the timestamp of logs from their content
+  <filter **>
+    @type record_modifier
+    <record>
+      _dummy_ ${ts = (m = record['message'].to_s.match(/^\[(?<timestamp>[^\]]+)\]/)) && m['timestamp']; if ts; begin; record['@timestamp'] = Time.at((@clo_time_parser ||= Fluent::TimeParser.new('%d/%b/%Y:%H:%M:%S', false, 'Europe/Paris')).parse(ts.to_s).to_r).utc.iso8601(6); rescue; record['unparsed_timestamp'] = ts; end; end; nil}
+    </record>
+    remove_keys _dummy_
+  </filter>
+  
+  <match **>
+    @type relabel
+    @label @ES_APP_OUT
+  </match>
 </label>`,
 		}),
 	)
@@ -19,10 +19,19 @@ const JournalLogTemplate = `
     # is the name of a directory - see fluentd storage_local.rb
     path '/var/lib/fluentd/pos/journal_pos.json'
   </storage>
+  {{- if .Matches}}
+  matches '{{.Matches}}'
+  {{- else}}
   matches "#{ENV['JOURNAL_FILTERS_JSON'] || '[]'}"
+  {{- end}}
   tag journal
   read_from_head "#{if (val = ENV.fetch('JOURNAL_READ_FROM_HEAD','')) && (val.length > 0); val; else 'false'; end}"
 </source>
 {{end}}`
 
-type JournalLog = generator.ConfLiteral
+// JournalLog reads the journal of the node. Matches, if defined, is the JSON list of the journal matches
+// selecting records, otherwise matches are read from the environment.
+type JournalLog struct {
+	generator.ConfLiteral
+	Matches string
+}
@@ -1,11 +1,13 @@
 package fluentd
 
 import (
+	"encoding/json"
 	"fmt"
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	"github.com/openshift/cluster-logging-operator/internal/constants"
 	"github.com/openshift/cluster-logging-operator/internal/generator"
 	source2 "github.com/openshift/cluster-logging-operator/internal/generator/fluentd/source"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	"strings"
 )
 
@@ -36,10 +38,13 @@ func LogSources(spec *logging.ClusterLogForwarderSpec, tunings *logging.FluentdI
 	if types.Has(logging.InputNameInfrastructure) {
 		el = append(el,
 			source2.JournalLog{
-				Desc:         "Logs from linux journal",
-				OutLabel:     "INGRESS",
-				TemplateName: "inputSourceJournalTemplate",
-				TemplateStr:  source2.JournalLogTemplate,
+				ConfLiteral: generator.ConfLiteral{
+					Desc:         "Logs from linux journal",
+					OutLabel:     "INGRESS",
+					TemplateName: "inputSourceJournalTemplate",
+					TemplateStr:  source2.JournalLogTemplate,
+				},
+				Matches: JournalMatches(spec),
 			})
 	}
 	if types.Has(logging.InputNameApplication) || types.Has(logging.InputNameInfrastructure) {
@@ -96,6 +101,31 @@ func LogSources(spec *logging.ClusterLogForwarderSpec, tunings *logging.FluentdI
 	return el
 }
 
+// JournalMatches returns the JSON list of the journal matches selecting the records of the units, priorities and
+// transports of the infrastructure inputs, or "" if the whole journal is collected. Values of a field are ORed,
+// fields are ANDed. Excluded units are dropped by the ingress pipeline.
+func JournalMatches(spec *logging.ClusterLogForwarderSpec) string {
+	filter := genhelper.InfrastructureJournal(spec)
+	if filter == nil {
+		return ""
+	}
+	match := map[string][]string{}
+	if len(filter.IncludeUnits) > 0 {
+		match["_SYSTEMD_UNIT"] = genhelper.JournalUnits(filter.IncludeUnits)
+	}
+	if filter.MaxPriority != "" {
+		match["PRIORITY"] = genhelper.JournalPriorities(filter.MaxPriority)
+	}
+	if len(filter.Transports) > 0 {
+		match["_TRANSPORT"] = genhelper.JournalTransports(filter.Transports)
+	}
+	if len(match) == 0 {
+		return ""
+	}
+	matches, _ := json.Marshal([]map[string][]string{match})
+	return string(matches)
+}
+
 func ContainerLogPaths() string {
 	return fmt.Sprintf("%q", "/var/log/pods/*/*/*.log")
 }
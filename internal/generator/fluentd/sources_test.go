@@ -129,6 +129,71 @@ var _ = Describe("Testing Config Generation", func() {
   read_from_head "#{if (val = ENV.fetch('JOURNAL_READ_FROM_HEAD','')) && (val.length > 0); val; else 'false'; end}"
 </source>
 
+# Logs from containers (including openshift containers)
+<source>
+  @type tail
+  @id container-input
+  path "/var/log/pods/*/*/*.log"
+  exclude_path ["/var/log/pods/openshift-logging_collector-*/*/*.log", "/var/log/pods/openshift-logging_elasticsearch-*/*/*.log", "/var/log/pods/openshift-logging_kibana-*/*/*.log", "/var/log/pods/*/*/*.gz", "/var/log/pods/*/*/*.tmp"]
+  pos_file "/var/lib/fluentd/pos/es-containers.log.pos"
+  follow_inodes true
+  refresh_interval 5
+  rotate_wait 5
+  tag kubernetes.*
+  read_from_head "true"
+  skip_refresh_on_startup true
+  @label @CONCAT
+  <parse>
+    @type regexp
+    expression /^(?<@timestamp>[^\s]+) (?<stream>stdout|stderr) (?<logtag>[F|P]) (?<message>.*)$/
+    time_key '@timestamp'
+    keep_time_key true
+  </parse>
+</source>
+`,
+		}),
+		Entry("Infrastructure with journal filters", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "nodes",
+						Infrastructure: &logging.Infrastructure{
+							Journal: &logging.Journal{
+								IncludeUnits: []string{"kubelet", "crio.service"},
+								ExcludeUnits: []string{"systemd-journald"},
+								MaxPriority:  "info",
+								Transports:   []logging.JournalTransport{logging.JournalTransportJournal, logging.JournalTransportStdout},
+							},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"nodes"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `
+# Logs from linux journal
+<source>
+  @type systemd
+  @id systemd-input
+  @label @INGRESS
+  path '/var/log/journal'
+  <storage>
+    @type local
+    persistent true
+    # NOTE: if this does not end in .json, fluentd will think it
+    # is the name of a directory - see fluentd storage_local.rb
+    path '/var/lib/fluentd/pos/journal_pos.json'
+  </storage>
+  matches '[{"PRIORITY":["0","1","2","3","4","5","6"],"_SYSTEMD_UNIT":["kubelet.service","crio.service"],"_TRANSPORT":["journal","stdout"]}]'
+  tag journal
+  read_from_head "#{if (val = ENV.fetch('JOURNAL_READ_FROM_HEAD','')) && (val.length > 0); val; else 'false'; end}"
+</source>
+
 # Logs from containers (including openshift containers)
 <source>
   @type tail
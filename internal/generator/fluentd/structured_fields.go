@@ -0,0 +1,63 @@
+package fluentd
+
+import (
+	"fmt"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	. "github.com/openshift/cluster-logging-operator/internal/generator"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/fluentd/elements"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+)
+
+// coercions are the ruby expressions converting a value `v` to a type, raising an error when it cannot be converted
+var coercions = map[logging.FieldType]string{
+	logging.FieldTypeString:    `v.is_a?(Enumerable) ? raise('not a scalar') : v.to_s`,
+	logging.FieldTypeInteger:   `Integer(v)`,
+	logging.FieldTypeFloat:     `Float(v)`,
+	logging.FieldTypeBoolean:   `case v.to_s.downcase when 'true', '1' then true when 'false', '0' then false else raise('not a boolean') end`,
+	logging.FieldTypeTimestamp: `Time.parse(v.to_s).utc.iso8601(6)`,
+}
+
+// StructuredFields returns the filter coercing the declared structured fields of a pipeline to their type and
+// protecting the undeclared top level structured fields from type conflicts
+func StructuredFields(p logging.PipelineSpec) Element {
+	spec := p.StructuredFields
+	if spec == nil || (len(spec.Types) == 0 && !genhelper.ProtectsStructuredFields(p)) {
+		return Nil
+	}
+	ruby := []string{fmt.Sprintf("s = record['%s']; if s.is_a?(Hash)", genhelper.StructuredField(p))}
+	for _, path := range genhelper.DeclaredFields(spec) {
+		segments := strings.Split(path, ".")
+		parent := "s"
+		if len(segments) > 1 {
+			parent = fmt.Sprintf("s.dig('%s')", strings.Join(segments[:len(segments)-1], "', '"))
+		}
+		ruby = append(ruby, fmt.Sprintf("f = %s; if f.is_a?(Hash) && f.key?('%[2]s'); begin; v = f['%[2]s']; f['%[2]s'] = (%[3]s); rescue; f.delete('%[2]s'); end; end",
+			parent, segments[len(segments)-1], coercions[spec.Types[path]]))
+	}
+	undeclared := ""
+	if len(spec.Types) != 0 {
+		undeclared = fmt.Sprintf("!['%s'].include?(k) && ", strings.Join(genhelper.DeclaredTopLevelFields(spec), "', '"))
+	}
+	switch spec.Conflicts {
+	case logging.StructuredFieldConflictsSuffix:
+		ruby = append(ruby, fmt.Sprintf("s.keys.each do |k| t = case s[k] when String then 'str' when Numeric then 'num' when true, false then 'bool' end; s[k + '_' + t] = s.delete(k) if %st; end", undeclared))
+	case logging.StructuredFieldConflictsStringify:
+		ruby = append(ruby, fmt.Sprintf("s.keys.each do |k| v = s[k]; s[k] = v.to_s if %s(v.is_a?(Numeric) || v == true || v == false); end", undeclared))
+	}
+	ruby = append(ruby, "end; nil")
+	return Filter{
+		Desc:      "Coerce structured fields and protect them from type conflicts",
+		MatchTags: "**",
+		Element: RecordModifier{
+			Records: []Record{
+				{
+					Key:        "_dummy_",
+					Expression: fmt.Sprintf("${%s}", strings.Join(ruby, "; ")),
+				},
+			},
+			RemoveKeys: []string{"_dummy_"},
+		},
+	}
+}
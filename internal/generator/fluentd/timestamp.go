@@ -0,0 +1,45 @@
+package fluentd
+
+import (
+	"fmt"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	. "github.com/openshift/cluster-logging-operator/internal/generator"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/fluentd/elements"
+	"github.com/openshift/cluster-logging-operator/internal/generator/fluentd/helpers"
+)
+
+// ExtractTimestamp returns the filter setting the @timestamp of records from their content,
+// records keep their collection time and record the unparsed time when it cannot be parsed.
+// The time parser is built once by the filter and reused for every record
+func ExtractTimestamp(spec *logging.TimestampSpec) Element {
+	if spec == nil {
+		return Nil
+	}
+	ts := fmt.Sprintf("ts = record.dig(%s)", rubyStrings(strings.Split(spec.Field, ".")))
+	if spec.Field == "" {
+		ts = fmt.Sprintf("ts = (m = record['message'].to_s.match(/%s/)) && m['timestamp']", RegexpLiteral(spec.Pattern))
+	}
+	quote := func(s string) string {
+		if s == "" {
+			return "nil"
+		}
+		return helpers.RubyString(s)
+	}
+	parse := fmt.Sprintf("if ts; begin; record['@timestamp'] = Time.at((@clo_time_parser ||= Fluent::TimeParser.new(%s, false, %s)).parse(ts.to_s).to_r).utc.iso8601(6); rescue; record['%s'] = ts; end; end; nil",
+		quote(spec.Format), quote(spec.Timezone), logging.UnparsedTimestampField)
+	return Filter{
+		Desc:      "Extract the timestamp of logs from their content",
+		MatchTags: "**",
+		Element: RecordModifier{
+			Records: []Record{
+				{
+					Key:        "_dummy_",
+					Expression: fmt.Sprintf("${%s; %s}", ts, parse),
+				},
+			},
+			RemoveKeys: []string{"_dummy_"},
+		},
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/openshift/cluster-logging-operator/internal/generator/fluentd"
 	"github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector"
+	vectoroutput "github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
+	gcloutput "github.com/openshift/cluster-logging-operator/internal/generator/vector/output/gcl"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -25,18 +27,26 @@ var (
 	ErrTLSOutputNoSecret = func(o logging.OutputSpec) error {
 		return fmt.Errorf("No secret defined in output %s, but URL has TLS Scheme %s", o.Name, o.URL)
 	}
-	ErrGCL = errors.New("Exactly one of billingAccountId, folderId, organizationId, or projectId must be set.")
+	ErrGCL      = errors.New("Exactly one of billingAccountId, folderId, organizationId, or projectId must be set.")
+	ErrGCLLogID = func(o logging.OutputSpec) error {
+		return fmt.Errorf("Invalid logId %q in %s output in ClusterLogForwarder", o.GoogleCloudLogging.LogID, o.Name)
+	}
+	ErrDeliveryMode = func(o logging.OutputSpec, collectorType logging.LogCollectionType) error {
+		return fmt.Errorf("Delivery mode %s of %s output is not supported for output type %s by the %s collector", o.Tuning.DeliveryMode, o.Name, o.Type, collectorType)
+	}
 )
 
 type ConfigGenerator struct {
-	g      generator.Generator
-	conf   func(clspec *logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec *logging.ClusterLogForwarderSpec, op generator.Options) []generator.Section
-	format func(conf string) string
+	collectorType logging.LogCollectionType
+	g             generator.Generator
+	conf          func(clspec *logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec *logging.ClusterLogForwarderSpec, op generator.Options) []generator.Section
+	format        func(conf string) string
 }
 
 func New(collectorType logging.LogCollectionType) *ConfigGenerator {
 	g := &ConfigGenerator{
-		format: func(conf string) string { return conf },
+		collectorType: collectorType,
+		format:        func(conf string) string { return conf },
 	}
 	switch collectorType {
 	case logging.LogCollectionTypeFluentd:
@@ -60,7 +70,7 @@ func (cg *ConfigGenerator) GenerateConf(clspec *logging.CollectionSpec, secrets
 func (cg *ConfigGenerator) Verify(clspec *logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec *logging.ClusterLogForwarderSpec, op generator.Options) error {
 	var err error
 	types := generator.GatherSources(clfspec, op)
-	if !types.HasAny(logging.InputNameApplication, logging.InputNameInfrastructure, logging.InputNameAudit) {
+	if !types.HasAny(logging.InputNameApplication, logging.InputNameInfrastructure, logging.InputNameAudit, logging.InputNameEvents, logging.InputNameFile, logging.InputNameReceiver) {
 		return ErrNoValidInputs
 	}
 	if len(clfspec.Outputs) == 0 {
@@ -93,6 +103,14 @@ func (cg *ConfigGenerator) Verify(clspec *logging.CollectionSpec, secrets map[st
 			if i > 1 {
 				return ErrGCL
 			}
+			if !gcloutput.IsValidLogID(gcl.LogID) {
+				return ErrGCLLogID(o)
+			}
+		}
+		if o.Tuning != nil && o.Tuning.DeliveryMode == logging.OutputDeliveryModeAtLeastOnce {
+			if cg.collectorType != logging.LogCollectionTypeVector || !vectoroutput.SupportsAcknowledgements(o.Type) {
+				return ErrDeliveryMode(o, cg.collectorType)
+			}
 		}
 	}
 	return err
@@ -0,0 +1,85 @@
+package helpers
+
+import (
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+)
+
+// FieldMove moves a field of the ViaQ data model to its dot separated path in another data model
+type FieldMove struct {
+	From string
+	To   string
+}
+
+// FieldValue sets a field of records to a constant value
+type FieldValue struct {
+	Path  string
+	Value string
+}
+
+// dataModelMoves translate records from the ViaQ data model. Moves apply in order, so the remaining
+// `kubernetes` and `openshift` fields move with their parent once the translated ones are gone.
+// OTel keeps the ViaQ fields without a semantic convention below `attributes`,
+// ECS keeps them below `kubernetes` and `openshift` as custom fields.
+// Other top level fields are unchanged.
+var dataModelMoves = map[logging.DataModelType][]FieldMove{
+	logging.DataModelOTel: {
+		{From: "@timestamp", To: "timestamp"},
+		{From: "message", To: "body"},
+		{From: "level", To: "severity_text"},
+		{From: "log_type", To: "attributes.log_type"},
+		{From: "hostname", To: "resource.host.name"},
+		{From: "openshift.cluster_id", To: "resource.k8s.cluster.uid"},
+		{From: "openshift.cluster_name", To: "resource.k8s.cluster.name"},
+		{From: "kubernetes.host", To: "resource.k8s.node.name"},
+		{From: "kubernetes.node_labels", To: "resource.k8s.node.label"},
+		{From: "kubernetes.namespace_name", To: "resource.k8s.namespace.name"},
+		{From: "kubernetes.namespace_id", To: "resource.k8s.namespace.uid"},
+		{From: "kubernetes.namespace_labels", To: "resource.k8s.namespace.label"},
+		{From: "kubernetes.pod_name", To: "resource.k8s.pod.name"},
+		{From: "kubernetes.pod_id", To: "resource.k8s.pod.uid"},
+		{From: "kubernetes.labels", To: "resource.k8s.pod.label"},
+		{From: "kubernetes.annotations", To: "resource.k8s.pod.annotation"},
+		{From: "kubernetes.container_name", To: "resource.k8s.container.name"},
+		{From: "kubernetes.container_id", To: "resource.container.id"},
+		{From: "kubernetes.container_image", To: "resource.container.image.name"},
+		{From: "kubernetes", To: "attributes.kubernetes"},
+		{From: "openshift", To: "attributes.openshift"},
+	},
+	logging.DataModelECS: {
+		{From: "level", To: "log.level"},
+		{From: "log_type", To: "event.dataset"},
+		{From: "openshift.labels", To: "labels"},
+		{From: "openshift.cluster_id", To: "orchestrator.cluster.id"},
+		{From: "openshift.cluster_name", To: "orchestrator.cluster.name"},
+		{From: "hostname", To: "host.name"},
+		{From: "kubernetes.namespace_name", To: "orchestrator.namespace"},
+		{From: "kubernetes.pod_name", To: "orchestrator.resource.name"},
+		{From: "kubernetes.pod_id", To: "orchestrator.resource.id"},
+		{From: "kubernetes.workload_kind", To: "orchestrator.resource.parent.type"},
+		{From: "kubernetes.container_name", To: "container.name"},
+		{From: "kubernetes.container_id", To: "container.id"},
+		{From: "kubernetes.container_image", To: "container.image.name"},
+	},
+}
+
+// dataModelValues are the constant fields of records in a data model
+var dataModelValues = map[logging.DataModelType][]FieldValue{
+	logging.DataModelECS: {
+		{Path: "ecs.version", Value: "8.0.0"},
+	},
+}
+
+// HasDataModel returns true if records are translated from the ViaQ data model for the output
+func HasDataModel(o logging.OutputSpec) bool {
+	return o.DataModel != "" && o.DataModel != logging.DataModelViaQ
+}
+
+// DataModelMoves returns the moves of fields translating records from the ViaQ data model to the data model of the output
+func DataModelMoves(o logging.OutputSpec) []FieldMove {
+	return dataModelMoves[o.DataModel]
+}
+
+// DataModelValues returns the constant fields of records in the data model of the output
+func DataModelValues(o logging.OutputSpec) []FieldValue {
+	return dataModelValues[o.DataModel]
+}
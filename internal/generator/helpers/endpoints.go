@@ -0,0 +1,13 @@
+package helpers
+
+import (
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+)
+
+// OutputURLs returns the URLs of an output, `urls` if set otherwise `url`
+func OutputURLs(o logging.OutputSpec) []string {
+	if len(o.URLs) > 0 {
+		return o.URLs
+	}
+	return []string{o.URL}
+}
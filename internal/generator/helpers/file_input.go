@@ -0,0 +1,96 @@
+package helpers
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/constants"
+)
+
+// FileInputRoot is the directory of the nodes below which file inputs may collect logs
+const FileInputRoot = "/var/log"
+
+var (
+	// builtinInputDirs are the directories read by the built-in inputs, file inputs may not collect their files
+	// again as application logs
+	builtinInputDirs = []string{
+		constants.ContainerLogDir,
+		constants.PodLogDir,
+		"/var/log/audit",
+		"/var/log/journal",
+		"/var/log/kube-apiserver",
+		"/var/log/oauth-apiserver",
+		"/var/log/openshift-apiserver",
+	}
+	// builtinInputFiles are the files read by the built-in inputs in directories shared with other logs
+	builtinInputFiles = []string{
+		"/var/log/ovn/acl-audit-log.log",
+	}
+)
+
+// FileInputDir returns the directory of a path glob that contains all matching files,
+// the longest leading directory without glob characters
+func FileInputDir(path string) string {
+	if i := strings.IndexAny(path, "*?[{"); i >= 0 {
+		path = path[:i]
+		if strings.HasSuffix(path, "/") {
+			return filepath.Clean(path)
+		}
+	}
+	return filepath.Dir(path)
+}
+
+// VerifyFileInputPath returns an error if a path glob may match files outside a directory below FileInputRoot,
+// or files read by the built-in inputs
+func VerifyFileInputPath(path string) error {
+	if !filepath.IsAbs(path) || filepath.Clean(path) != path {
+		return fmt.Errorf("path %q must be absolute and clean", path)
+	}
+	if !strings.HasPrefix(FileInputDir(path), FileInputRoot+"/") {
+		return fmt.Errorf("path %q must select files in a directory below %s", path, FileInputRoot)
+	}
+	if IsWithinDirs(FileInputDir(path), builtinInputDirs) {
+		return fmt.Errorf("path %q must not select files in %v, they are collected by the built-in inputs", path, builtinInputDirs)
+	}
+	for _, file := range builtinInputFiles {
+		if matched, _ := filepath.Match(path, file); matched {
+			return fmt.Errorf("path %q must not select %s, it is collected by the built-in inputs", path, file)
+		}
+	}
+	return nil
+}
+
+// FileInputDirs returns the sorted directories of the nodes read by the file inputs,
+// directories within another one of the list are left out
+func FileInputDirs(spec logging.ClusterLogForwarderSpec) []string {
+	dirs := []string{}
+	for _, input := range spec.Inputs {
+		if input.File == nil {
+			continue
+		}
+		for _, path := range input.File.Paths {
+			dirs = append(dirs, FileInputDir(path))
+		}
+	}
+	sort.Strings(dirs)
+	result := []string{}
+	for _, dir := range dirs {
+		if !IsWithinDirs(dir, result) {
+			result = append(result, dir)
+		}
+	}
+	return result
+}
+
+// IsWithinDirs returns true if dir is one of dirs or a subdirectory of one of them
+func IsWithinDirs(dir string, dirs []string) bool {
+	for _, d := range dirs {
+		if dir == d || strings.HasPrefix(dir, d+"/") {
+			return true
+		}
+	}
+	return false
+}
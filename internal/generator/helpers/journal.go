@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"strconv"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+)
+
+// InfrastructureJournal returns the journal filter of the infrastructure inputs referenced by pipelines, or nil if
+// the whole journal is collected. Filters of all the inputs are expected to be the same.
+func InfrastructureJournal(spec *logging.ClusterLogForwarderSpec) *logging.Journal {
+	routes := logging.NewRoutes(spec.Pipelines)
+	if _, ok := routes.ByInput[logging.InputNameInfrastructure]; ok {
+		return nil
+	}
+	var journal *logging.Journal
+	for _, input := range spec.Inputs {
+		if _, ok := routes.ByInput[input.Name]; !ok || input.Infrastructure == nil {
+			continue
+		}
+		if input.Infrastructure.Journal == nil {
+			return nil
+		}
+		journal = input.Infrastructure.Journal
+	}
+	return journal
+}
+
+// JournalUnits returns the names of systemd units, completing names without a suffix with `.service`
+func JournalUnits(units []string) []string {
+	names := []string{}
+	for _, unit := range units {
+		if !strings.Contains(unit, ".") {
+			unit += ".service"
+		}
+		names = append(names, unit)
+	}
+	return names
+}
+
+// JournalPriorities returns the values of the journal PRIORITY field up to the least severe priority max,
+// or nil if max is not a priority
+func JournalPriorities(max string) []string {
+	for i, name := range logging.JournalPriorities {
+		if name == max {
+			values := []string{}
+			for p := 0; p <= i; p++ {
+				values = append(values, strconv.Itoa(p))
+			}
+			return values
+		}
+	}
+	return nil
+}
+
+// JournalTransports returns the values of the journal _TRANSPORT field
+func JournalTransports(transports []logging.JournalTransport) []string {
+	values := []string{}
+	for _, t := range transports {
+		values = append(values, string(t))
+	}
+	return values
+}
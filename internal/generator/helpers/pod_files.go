@@ -0,0 +1,151 @@
+package helpers
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// PodVolumesDir is the directory of the nodes where the kubelet keeps the volumes of the pods
+const PodVolumesDir = "/var/lib/kubelet/pods"
+
+// PodFiles are the files listed by the annotation of a pod, as globs of the node
+type PodFiles struct {
+	Namespace string
+	Pod       string
+	PodID     string
+	Container string
+	Labels    map[string]string
+	Globs     []string
+}
+
+// PodVolumeKey returns the UID of the pod and the name of the emptyDir volume of a glob of the node, joined by a
+// slash, for example `6a0f4a45-1c1e-4b4e-9d4e-0e4b5c6f7a80/logs`
+func PodVolumeKey(glob string) string {
+	segments := strings.SplitN(strings.TrimPrefix(glob, PodVolumesDir+"/"), "/", 5)
+	if len(segments) < 4 {
+		return ""
+	}
+	return segments[0] + "/" + segments[3]
+}
+
+// ResolvePodFiles maps the absolute path globs of the containers listed by the files annotation of a pod to globs of
+// the node below the emptyDir volumes mounted by the containers. Paths outside emptyDir volumes are ignored.
+func ResolvePodFiles(pod *corev1.Pod) []PodFiles {
+	paths := []string{}
+	for _, path := range strings.Split(pod.Annotations[logging.PodFilesAnnotation], ",") {
+		if path = strings.TrimSpace(path); filepath.IsAbs(path) && filepath.Clean(path) == path {
+			paths = append(paths, path)
+		}
+	}
+	emptyDirs := sets.NewString()
+	for _, v := range pod.Spec.Volumes {
+		if v.EmptyDir != nil {
+			emptyDirs.Insert(v.Name)
+		}
+	}
+	files := []PodFiles{}
+	resolved := sets.NewString()
+	for _, c := range pod.Spec.Containers {
+		globs := []string{}
+		for _, path := range paths {
+			if resolved.Has(path) {
+				continue
+			}
+			if glob := volumeGlob(pod, c, emptyDirs, path); glob != "" {
+				globs = append(globs, glob)
+				resolved.Insert(path)
+			}
+		}
+		if len(globs) > 0 {
+			files = append(files, PodFiles{
+				Namespace: pod.Namespace,
+				Pod:       pod.Name,
+				PodID:     string(pod.UID),
+				Container: c.Name,
+				Labels:    pod.Labels,
+				Globs:     globs,
+			})
+		}
+	}
+	return files
+}
+
+// volumeGlob returns the glob of the node for a path of the container in the emptyDir volume mounted at the longest
+// leading directory of the path, or "" if there is no such volume
+func volumeGlob(pod *corev1.Pod, c corev1.Container, emptyDirs sets.String, path string) string {
+	var mount *corev1.VolumeMount
+	for i, m := range c.VolumeMounts {
+		if m.SubPath != "" || m.SubPathExpr != "" || !emptyDirs.Has(m.Name) {
+			continue
+		}
+		if !strings.HasPrefix(path, strings.TrimSuffix(m.MountPath, "/")+"/") {
+			continue
+		}
+		if mount == nil || len(m.MountPath) > len(mount.MountPath) {
+			mount = &c.VolumeMounts[i]
+		}
+	}
+	if mount == nil {
+		return ""
+	}
+	rel := strings.TrimPrefix(path, strings.TrimSuffix(mount.MountPath, "/")+"/")
+	return filepath.Join(PodVolumesDir, string(pod.UID), "volumes", "kubernetes.io~empty-dir", mount.Name, rel)
+}
+
+// HasAnnotatedFiles returns true if a pipeline references an application input enabling annotated files
+func HasAnnotatedFiles(spec *logging.ClusterLogForwarderSpec) bool {
+	routes := logging.NewRoutes(spec.Pipelines)
+	for _, input := range spec.Inputs {
+		if _, ok := routes.ByInput[input.Name]; ok && input.Application != nil && input.Application.AnnotatedFiles {
+			return true
+		}
+	}
+	return false
+}
+
+// InputPodFiles returns the files of the pods resolved by the operator that are selected by an application input,
+// sorted by pod
+func InputPodFiles(input logging.InputSpec, op generator.Options) []PodFiles {
+	all, _ := op[generator.PodFiles].([]PodFiles)
+	if input.Application == nil || !input.Application.AnnotatedFiles || len(all) == 0 {
+		return nil
+	}
+	namespaces := sets.NewString(input.Application.Namespaces...)
+	selector, err := metav1.LabelSelectorAsSelector(input.Application.Selector)
+	if err != nil {
+		return nil
+	}
+	files := []PodFiles{}
+	for _, f := range all {
+		if namespaces.Len() > 0 && !namespaces.Has(f.Namespace) {
+			continue
+		}
+		if input.Application.Selector != nil && !selector.Matches(labels.Set(f.Labels)) {
+			continue
+		}
+		files = append(files, f)
+	}
+	SortPodFiles(files)
+	return files
+}
+
+// SortPodFiles sorts files by namespace, pod and container
+func SortPodFiles(files []PodFiles) {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Namespace != files[j].Namespace {
+			return files[i].Namespace < files[j].Namespace
+		}
+		if files[i].Pod != files[j].Pod {
+			return files[i].Pod < files[j].Pod
+		}
+		return files[i].Container < files[j].Container
+	})
+}
@@ -0,0 +1,47 @@
+package helpers
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/constants"
+)
+
+// ReceiverCertDir is the directory of the collector where the serving certificates of receivers are mounted
+const ReceiverCertDir = "/etc/collector/receiver"
+
+// ReceiverInputs returns the receiver inputs referenced by pipelines sorted by name
+func ReceiverInputs(spec *logging.ClusterLogForwarderSpec) []logging.InputSpec {
+	routes := logging.NewRoutes(spec.Pipelines)
+	inputs := []logging.InputSpec{}
+	for _, input := range spec.Inputs {
+		if _, ok := routes.ByInput[input.Name]; ok && input.Receiver != nil {
+			inputs = append(inputs, input)
+		}
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].Name < inputs[j].Name })
+	return inputs
+}
+
+// ReceiverServiceName returns the name of the service exposing a receiver input and of its serving certificate secret
+func ReceiverServiceName(input string) string {
+	return fmt.Sprintf("%s-%s", constants.CollectorName, input)
+}
+
+// ReceiverCertPath returns the path of a file of the serving certificate of a receiver input
+func ReceiverCertPath(input, file string) string {
+	return filepath.Join(ReceiverCertDir, input, file)
+}
+
+// ReceiverSecretKey returns the key of the client secret of a receiver input in the secrets passed to the generators,
+// distinct from the output names keying the output secrets
+func ReceiverSecretKey(input string) string {
+	return fmt.Sprintf("input/%s", input)
+}
+
+// IsUDPReceiver returns true if the receiver listens on UDP, without TLS
+func IsUDPReceiver(receiver *logging.ReceiverSpec) bool {
+	return receiver.Type == logging.ReceiverTypeSyslog && receiver.Syslog != nil && receiver.Syslog.Protocol == "udp"
+}
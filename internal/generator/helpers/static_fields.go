@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+)
+
+const (
+	ClusterIDField   = "openshift.cluster_id"
+	ClusterNameField = "openshift.cluster_name"
+)
+
+// StaticFields returns the fields stamped onto all records, the identity of the cluster if enabled
+// followed by the static fields of the forwarder sorted by path
+func StaticFields(spec *logging.ClusterLogForwarderSpec, op generator.Options) []FieldValue {
+	fields := []FieldValue{}
+	if spec.ClusterIdentity {
+		if id, ok := op[generator.ClusterID]; ok && id != "" {
+			fields = append(fields, FieldValue{Path: ClusterIDField, Value: fmt.Sprintf("%v", id)})
+		}
+		if name, ok := op[generator.ClusterName]; ok && name != "" {
+			fields = append(fields, FieldValue{Path: ClusterNameField, Value: fmt.Sprintf("%v", name)})
+		}
+	}
+	paths := make([]string, 0, len(spec.StaticFields))
+	for path := range spec.StaticFields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fields = append(fields, FieldValue{Path: path, Value: spec.StaticFields[path]})
+	}
+	return fields
+}
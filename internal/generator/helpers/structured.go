@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"sort"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// KlogPattern matches the header of klog messages, https://github.com/kubernetes/klog. Both collectors parse
+// klog messages with it, into the same `level`, `timestamp`, `id`, `file`, `line` and `message` string fields.
+const KlogPattern = `^(?<level>[IWEF])(?<timestamp>\d{4} \d{2}:\d{2}:\d{2}\.\d+)\s+(?<id>\d+) (?<file>[^:]+):(?<line>\d+)\] (?<message>.*)$`
+
+// StructuredField returns the top level field receiving the parsed message of a pipeline
+func StructuredField(p logging.PipelineSpec) string {
+	if p.Parser != nil && p.Parser.TargetField != "" {
+		return p.Parser.TargetField
+	}
+	return logging.DefaultParserTargetField
+}
+
+// ProtectsStructuredFields returns true if the undeclared structured fields of a pipeline are protected from type conflicts
+func ProtectsStructuredFields(p logging.PipelineSpec) bool {
+	return p.StructuredFields != nil && p.StructuredFields.Conflicts != "" && p.StructuredFields.Conflicts != logging.StructuredFieldConflictsNone
+}
+
+// DeclaredFields returns the sorted paths of the structured fields with a declared type
+func DeclaredFields(spec *logging.StructuredFieldsSpec) []string {
+	paths := []string{}
+	for path := range spec.Types {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// DeclaredTopLevelFields returns the sorted, unique top level fields of the structured fields with a declared type
+func DeclaredTopLevelFields(spec *logging.StructuredFieldsSpec) []string {
+	fields := sets.NewString()
+	for path := range spec.Types {
+		fields.Insert(strings.SplitN(path, ".", 2)[0])
+	}
+	return fields.List()
+}
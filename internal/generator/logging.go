@@ -9,6 +9,16 @@ import (
 const (
 	IncludeLegacyForwardConfig = "includeLegacyForwardConfig"
 	UseOldRemoteSyslogPlugin   = "useOldRemoteSyslogPlugin"
+	// ClusterName is the name of the cluster as read from the Infrastructure resource
+	ClusterName = "clusterName"
+	// ClusterLocation is the GCP region of the cluster as read from the Infrastructure resource
+	ClusterLocation = "clusterLocation"
+	// ClusterID is the ID of the cluster as read from the ClusterVersion resource
+	ClusterID = "clusterID"
+	// EventRouterNamespace is the namespace of the event router deployed by the operator
+	EventRouterNamespace = "eventRouterNamespace"
+	// PodFiles are the files listed by the annotations of the pods, as resolved by the operator
+	PodFiles = "podFiles"
 )
 
 //GatherSources collects the set of unique source types and namespaces
@@ -28,6 +38,15 @@ func GatherSources(forwarder *logging.ClusterLogForwarderSpec, op Options) sets.
 			if spec.Audit != nil {
 				types.Insert(logging.InputNameAudit)
 			}
+			if spec.Events != nil {
+				types.Insert(logging.InputNameEvents)
+			}
+			if spec.File != nil {
+				types.Insert(logging.InputNameFile)
+			}
+			if spec.Receiver != nil {
+				types.Insert(logging.InputNameReceiver)
+			}
 		}
 	}
 	return types
@@ -0,0 +1,149 @@
+package vector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+)
+
+const (
+	AuditFilterLogs = "audit_%s"
+
+	// AuditEventFields extracts the fields of API audit events matched by the rules of audit filters
+	AuditEventFields = `
+stage = string(.stage) ?? ""
+verb = string(.verb) ?? ""
+user = string(.user.username) ?? ""
+namespace = string(.objectRef.namespace) ?? ""
+group = string(.objectRef.apiGroup) ?? ""
+resource = string(.objectRef.resource) ?? ""
+subresource = string(.objectRef.subresource) ?? ""
+if resource != "" && subresource != "" {
+  resource = resource + "/" + subresource
+}
+`
+	// ApplyAuditLevel drops the events of level None and the objects not recorded at their level
+	ApplyAuditLevel = `
+if includes(omit, stage) || level == "None" {
+  abort
+}
+if level == "Metadata" {
+  del(.requestObject)
+  del(.responseObject)
+} else if level == "Request" {
+  del(.responseObject)
+}
+`
+)
+
+// AuditFilterInputs returns the audit inputs referenced by pipelines defining a filter
+func AuditFilterInputs(spec *logging.ClusterLogForwarderSpec) []*logging.InputSpec {
+	inputs := []*logging.InputSpec{}
+	for _, input := range referencedInputs(spec) {
+		if input.Audit != nil && input.Audit.Filter != nil {
+			inputs = append(inputs, input)
+		}
+	}
+	return inputs
+}
+
+// FilterAuditLogs drops the API audit events of each audit input according to the rules of its filter
+func FilterAuditLogs(spec *logging.ClusterLogForwarderSpec) []generator.Element {
+	el := []generator.Element{}
+	for _, input := range AuditFilterInputs(spec) {
+		el = append(el, Remap{
+			Desc:        fmt.Sprintf("Filter API audit events of input %q", input.Name),
+			ComponentID: AuditInputID(input),
+			Inputs:      helpers.MakeInputs(logging.InputNameAudit),
+			VRL:         AuditFilter(input.Audit.Filter),
+		})
+	}
+	return el
+}
+
+// AuditFilter returns the VRL applying the rules of a filter to the Kubernetes and OpenShift API audit events
+func AuditFilter(filter *logging.AuditFilter) string {
+	rules := []string{}
+	for _, rule := range filter.Rules {
+		cond := AND(
+			auditIncludes("verb", rule.Verbs),
+			auditIncludes("user", rule.Users),
+			auditIncludes("namespace", rule.Namespaces),
+			auditResources(rule.Resources),
+		)
+		if cond == "" {
+			cond = "true"
+		}
+		rules = append(rules, fmt.Sprintf("if %s {\n  level = %q\n  omit = %s\n}", cond, rule.Level, vrlObject(auditStages(rule.OmitStages))))
+	}
+	vrl := strings.Join(helpers.TrimSpaces([]string{
+		AuditEventFields,
+		fmt.Sprintf("level = %q\nomit = []", logging.AuditLevelNone),
+		strings.Join(rules, " else "),
+		fmt.Sprintf("omit = append(omit, %s)", vrlObject(auditStages(filter.OmitStages))),
+		ApplyAuditLevel,
+	}), "\n")
+	return fmt.Sprintf("if .tag == %q || .tag == %q {\n%s\n}", K8sAuditLogTag, OpenAuditLogTag, indentVRL(vrl))
+}
+
+// auditIncludes returns the condition matching a variable to values, or "" if there are no values
+func auditIncludes(variable string, values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("includes(%s, %s)", vrlObject(values), variable)
+}
+
+// auditResources returns the condition matching the group and resource of a request, or "" if there are no resources
+func auditResources(resources []logging.AuditGroupResources) string {
+	conds := []string{}
+	for _, r := range resources {
+		match := auditIncludes("resource", r.Resources)
+		if match == "" {
+			match = `resource != ""`
+		}
+		conds = append(conds, AND(Eq("group", r.Group), match))
+	}
+	return OR(conds...)
+}
+
+func auditStages(stages []logging.AuditStage) []string {
+	values := []string{}
+	for _, s := range stages {
+		values = append(values, string(s))
+	}
+	return values
+}
+
+func indentVRL(vrl string) string {
+	lines := strings.Split(vrl, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = "  " + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AuditInputID returns the ID of the component emitting the records of an audit input
+func AuditInputID(input *logging.InputSpec) string {
+	if input.Audit != nil && input.Audit.Filter != nil {
+		return fmt.Sprintf(AuditFilterLogs, input.Name)
+	}
+	return logging.InputNameAudit
+}
+
+// vrlObject returns the literal of an object of strings
+func vrlObject(v interface{}) string {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+	return strings.TrimSpace(buf.String())
+}
@@ -10,12 +10,13 @@ import (
 func Conf(clspec *logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec *logging.ClusterLogForwarderSpec, op generator.Options) []generator.Section {
 	return []generator.Section{
 		{
-			Sources(clfspec, op),
+			Sources(clfspec, secrets, op),
 			`
 			Set of all input sources, as defined in CLF spec
 			 - kubernetes_logs
 			 - journald
 			 - file
+			 - http
 			 - internal_metrics
 			`,
 		},
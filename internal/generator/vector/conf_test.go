@@ -268,6 +268,25 @@ source = '''
   envelop = {}
   envelop |= {"type": match1.type}
   
+  payload = parse_regex(.message, r'msg=audit\([^)]*\):\s*(?P<payload>.*)$') ?? {}
+  fields = replace(string(payload.payload) ?? "", r'\x1d', " ")
+  # user space records nest their fields in a single quoted msg field
+  nested = parse_regex(fields, r'\bmsg=\'(?P<fields>[^\']*)\'') ?? {}
+  if exists(nested.fields) {
+    fields = replace(fields, r'\bmsg=\'[^\']*\'', "") + " " + (string(nested.fields) ?? "")
+  }
+  pairs = parse_regex_all(fields, r'(?P<key>[\w-]+)=(?P<value>"[^"]*"|\'[^\']*\'|\S*)') ?? []
+  for_each(pairs) -> |_index, pair| {
+    key = string(pair.key) ?? ""
+    value = string(pair.value) ?? ""
+    if starts_with(value, "\"") || starts_with(value, "'") {
+      value = slice(value, 1, -1) ?? value
+    } else if includes(["acct", "cmd", "comm", "cwd", "data", "exe", "key", "name", "path", "proctitle"], key) && match(value, r'^(?:[0-9A-F]{2})+$') {
+      value = replace(decode_base16(value) ?? value, r'\x00', " ")
+    }
+    envelop = set!(envelop, [key], value)
+  }
+  
   match2, err = parse_regex(.message, r'msg=audit\((?P<ts_record>[^ ]+)\):')
   if err == null {
     sp = split(match2.ts_record,":")
@@ -325,6 +344,24 @@ source = '''
       .level = "emergency"
     }
   }
+  acl, err = parse_regex(.message, r'\|acl_log\([^)]*\)\|[^|]*\|name="?(?P<name>[^",]*)"?, verdict=(?P<verdict>[^,:]+)(?:, severity=(?P<severity>[^,:]+))?(?:, direction=(?P<direction>[^,:]+))?(?:: (?P<flow>.*))?$')
+  if err == null {
+    flow = string(acl.flow) ?? ""
+    fields = parse_key_value(flow, key_value_delimiter: "=", field_delimiter: ",") ?? {}
+    .ovn = compact({
+      "acl_name": acl.name,
+      "verdict": acl.verdict,
+      "severity": acl.severity,
+      "direction": acl.direction,
+      "protocol": split(flow, ",")[0],
+      "src_ip": fields.nw_src,
+      "dst_ip": fields.nw_dst
+    })
+    if exists(fields.ipv6_src) { .ovn.src_ip = fields.ipv6_src }
+    if exists(fields.ipv6_dst) { .ovn.dst_ip = fields.ipv6_dst }
+    if exists(fields.tp_src) { .ovn.src_port = to_int(fields.tp_src) ?? fields.tp_src }
+    if exists(fields.tp_dst) { .ovn.dst_port = to_int(fields.tp_dst) ?? fields.tp_dst }
+  }
 '''
 
 [transforms.route_container_logs]
@@ -636,6 +673,25 @@ source = '''
   envelop = {}
   envelop |= {"type": match1.type}
   
+  payload = parse_regex(.message, r'msg=audit\([^)]*\):\s*(?P<payload>.*)$') ?? {}
+  fields = replace(string(payload.payload) ?? "", r'\x1d', " ")
+  # user space records nest their fields in a single quoted msg field
+  nested = parse_regex(fields, r'\bmsg=\'(?P<fields>[^\']*)\'') ?? {}
+  if exists(nested.fields) {
+    fields = replace(fields, r'\bmsg=\'[^\']*\'', "") + " " + (string(nested.fields) ?? "")
+  }
+  pairs = parse_regex_all(fields, r'(?P<key>[\w-]+)=(?P<value>"[^"]*"|\'[^\']*\'|\S*)') ?? []
+  for_each(pairs) -> |_index, pair| {
+    key = string(pair.key) ?? ""
+    value = string(pair.value) ?? ""
+    if starts_with(value, "\"") || starts_with(value, "'") {
+      value = slice(value, 1, -1) ?? value
+    } else if includes(["acct", "cmd", "comm", "cwd", "data", "exe", "key", "name", "path", "proctitle"], key) && match(value, r'^(?:[0-9A-F]{2})+$') {
+      value = replace(decode_base16(value) ?? value, r'\x00', " ")
+    }
+    envelop = set!(envelop, [key], value)
+  }
+  
   match2, err = parse_regex(.message, r'msg=audit\((?P<ts_record>[^ ]+)\):')
   if err == null {
     sp = split(match2.ts_record,":")
@@ -693,6 +749,24 @@ source = '''
       .level = "emergency"
     }
   }
+  acl, err = parse_regex(.message, r'\|acl_log\([^)]*\)\|[^|]*\|name="?(?P<name>[^",]*)"?, verdict=(?P<verdict>[^,:]+)(?:, severity=(?P<severity>[^,:]+))?(?:, direction=(?P<direction>[^,:]+))?(?:: (?P<flow>.*))?$')
+  if err == null {
+    flow = string(acl.flow) ?? ""
+    fields = parse_key_value(flow, key_value_delimiter: "=", field_delimiter: ",") ?? {}
+    .ovn = compact({
+      "acl_name": acl.name,
+      "verdict": acl.verdict,
+      "severity": acl.severity,
+      "direction": acl.direction,
+      "protocol": split(flow, ",")[0],
+      "src_ip": fields.nw_src,
+      "dst_ip": fields.nw_dst
+    })
+    if exists(fields.ipv6_src) { .ovn.src_ip = fields.ipv6_src }
+    if exists(fields.ipv6_dst) { .ovn.dst_ip = fields.ipv6_dst }
+    if exists(fields.tp_src) { .ovn.src_port = to_int(fields.tp_src) ?? fields.tp_src }
+    if exists(fields.tp_dst) { .ovn.dst_port = to_int(fields.tp_dst) ?? fields.tp_dst }
+  }
 '''
 
 [transforms.route_container_logs]
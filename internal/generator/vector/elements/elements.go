@@ -56,6 +56,67 @@ source = '''
 `
 }
 
+// Dedupe drops records whose Fields match those of a recently seen record
+type Dedupe struct {
+	ComponentID string
+	Desc        string
+	Inputs      string
+	Fields      []string
+}
+
+func (d Dedupe) Name() string {
+	return "dedupeTemplate"
+}
+
+func (d Dedupe) Template() string {
+	return `{{define "dedupeTemplate" -}}
+{{if .Desc -}}
+# {{.Desc}}
+{{end -}}
+[transforms.{{.ComponentID}}]
+type = "dedupe"
+inputs = {{.Inputs}}
+fields.match = [{{range $i, $f := .Fields}}{{if $i}}, {{end}}{{printf "%q" $f}}{{end}}]
+{{end}}
+`
+}
+
+// Reduce merges the records sharing the values of GroupBy until a record satisfies the EndsWhen condition
+// or no record is received for ExpireAfterMs
+type Reduce struct {
+	ComponentID   string
+	Desc          string
+	Inputs        string
+	GroupBy       []string
+	EndsWhen      string
+	ExpireAfterMs int
+	// MergeStrategies of fields not merged by the default strategy of their type
+	MergeStrategies map[string]string
+}
+
+func (r Reduce) Name() string {
+	return "reduceTemplate"
+}
+
+func (r Reduce) Template() string {
+	return `{{define "reduceTemplate" -}}
+{{if .Desc -}}
+# {{.Desc}}
+{{end -}}
+[transforms.{{.ComponentID}}]
+type = "reduce"
+inputs = {{.Inputs}}
+group_by = [{{range $i, $f := .GroupBy}}{{if $i}}, {{end}}{{printf "%q" $f}}{{end}}]
+{{- if .EndsWhen}}
+ends_when = '{{.EndsWhen}}'
+{{- end}}
+expire_after_ms = {{.ExpireAfterMs}}
+{{- range $field, $strategy := .MergeStrategies}}
+merge_strategies.{{$field}} = {{printf "%q" $strategy}}
+{{- end}}
+{{end}}`
+}
+
 func Debug(id string, inputs string) generator.Element {
 	return generator.ConfLiteral{
 		Desc:         "Sending records to stdout for debug purposes",
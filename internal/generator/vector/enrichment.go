@@ -0,0 +1,44 @@
+package vector
+
+import (
+	"fmt"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+)
+
+const (
+	// AddWorkload replaces the pod owner added by the kubernetes_logs source with the kind and name of the workload,
+	// pods of a ReplicaSet with a pod-template-hash label belong to a Deployment
+	AddWorkload = `
+if exists(.kubernetes.pod_owner) {
+  owner = split(to_string(del(.kubernetes.pod_owner)) ?? "", "/", limit: 2)
+  .kubernetes.workload_kind = owner[0]
+  .kubernetes.workload_name = owner[1]
+  if owner[0] == "ReplicaSet" && exists(.kubernetes.labels."pod-template-hash") {
+    .kubernetes.workload_kind = "Deployment"
+    .kubernetes.workload_name = replace(to_string(owner[1]) ?? "", r'-[a-z0-9]+$', "")
+  }
+}
+`
+)
+
+// EnrichKubernetesMetadata returns the VRL adding the workload of container records
+// and keeping the selected node labels
+func EnrichKubernetesMetadata(spec *logging.EnrichmentSpec) []string {
+	vrls := []string{}
+	if spec == nil {
+		return vrls
+	}
+	if spec.Workload {
+		vrls = append(vrls, AddWorkload)
+	}
+	if len(spec.NodeLabels) != 0 {
+		labels := []string{}
+		for _, key := range spec.NodeLabels {
+			labels = append(labels, fmt.Sprintf("%q: .kubernetes.node_labels.%q", key, key))
+		}
+		vrls = append(vrls, fmt.Sprintf("if exists(.kubernetes.node_labels) {\n  .kubernetes.node_labels = compact({%s})\n}", strings.Join(labels, ", ")))
+	}
+	return vrls
+}
@@ -0,0 +1,137 @@
+package vector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/constants"
+	. "github.com/openshift/cluster-logging-operator/internal/generator"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+)
+
+const (
+	RouteEvents = "route_events"
+
+	K8sEventNamespace = ".kubernetes.event.involvedObject.namespace"
+
+	// ParseEvent replaces the message written by the event router with the message of the event
+	// and keeps the event itself under kubernetes.event
+	ParseEvent = `
+parsed, err = parse_json(.message)
+if err == null && is_object(parsed.event) {
+  .kubernetes.event = parsed.event
+  .kubernetes.event.verb = parsed.verb
+  .message = .kubernetes.event.message
+  timestamp, err = to_timestamp(.kubernetes.event.lastTimestamp)
+  if err == null {
+    ."@timestamp" = timestamp
+  }
+  if .kubernetes.event.type == "Warning" {
+    .level = "warn"
+  } else {
+    .level = "info"
+  }
+}
+`
+)
+
+var (
+	// DedupeEventFields identify updates of an event that carry no new information
+	DedupeEventFields = []string{
+		"kubernetes.event.metadata.uid",
+		"kubernetes.event.type",
+		"kubernetes.event.reason",
+		"kubernetes.event.message",
+	}
+)
+
+// EventRouterLogs matches the container logs of the event router deployed by the operator
+// in the namespace of the options, openshift-logging by default
+func EventRouterLogs(op Options) string {
+	namespace := constants.OpenshiftNS
+	if ns, ok := op[EventRouterNamespace]; ok {
+		namespace = fmt.Sprintf("%v", ns)
+	}
+	return AND(
+		MatchNS(namespace),
+		MatchLabel(fmt.Sprintf("%q", constants.LabelK8sName), constants.EventRouterName))
+}
+
+// Events parses the event router logs and routes them to the user defined events inputs
+func Events(spec *logging.ClusterLogForwarderSpec) []Element {
+	el := []Element{
+		Remap{
+			Desc:        `Parse Kubernetes events and set log_type to "infrastructure"`,
+			ComponentID: logging.InputNameEvents,
+			Inputs:      helpers.MakeInputs("route_container_logs.events"),
+			VRL: strings.Join(helpers.TrimSpaces([]string{
+				AddLogTypeInfra,
+				ParseEvent,
+			}), "\n"),
+		},
+	}
+	inputs := EventsInputs(spec)
+	r := Route{
+		ComponentID: RouteEvents,
+		Inputs:      helpers.MakeInputs(logging.InputNameEvents),
+		Routes:      map[string]string{},
+	}
+	for _, input := range inputs {
+		matchNS := []string{}
+		for _, ns := range input.Events.Namespaces {
+			matchNS = append(matchNS, Eq(K8sEventNamespace, ns))
+		}
+		if len(matchNS) == 0 {
+			r.Routes[input.Name] = Quote("true")
+		} else {
+			r.Routes[input.Name] = Quote(OR(matchNS...))
+		}
+	}
+	el = append(el, r)
+	for _, input := range inputs {
+		if input.Events.Deduplicate {
+			el = append(el, Dedupe{
+				Desc:        fmt.Sprintf("Drop repeated updates of events for input %q", input.Name),
+				ComponentID: EventsInputID(input),
+				Inputs:      helpers.MakeInputs(fmt.Sprintf("%s.%s", RouteEvents, input.Name)),
+				Fields:      DedupeEventFields,
+			})
+		}
+	}
+	return el
+}
+
+// EventsInputs returns the events inputs referenced by pipelines sorted by name
+func EventsInputs(spec *logging.ClusterLogForwarderSpec) []*logging.InputSpec {
+	inputs := []*logging.InputSpec{}
+	for _, input := range referencedInputs(spec) {
+		if input.Events != nil {
+			inputs = append(inputs, input)
+		}
+	}
+	return inputs
+}
+
+// referencedInputs returns the user defined inputs referenced by pipelines sorted by name
+func referencedInputs(spec *logging.ClusterLogForwarderSpec) []*logging.InputSpec {
+	userDefined := spec.InputMap()
+	inputs := []*logging.InputSpec{}
+	for name := range logging.NewRoutes(spec.Pipelines).ByInput {
+		if input, ok := userDefined[name]; ok {
+			inputs = append(inputs, input)
+		}
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].Name < inputs[j].Name })
+	return inputs
+}
+
+// EventsInputID returns the ID of the component emitting the events of an input
+func EventsInputID(input *logging.InputSpec) string {
+	if input.Events.Deduplicate {
+		return fmt.Sprintf("%s_dedupe", input.Name)
+	}
+	return fmt.Sprintf("%s.%s", RouteEvents, input.Name)
+}
@@ -0,0 +1,71 @@
+package vector
+
+import (
+	"fmt"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/source"
+)
+
+const (
+	RawFileLogs = "raw_file_%s"
+	FileLogs    = "file_%s"
+)
+
+// FileInputs returns the file inputs referenced by pipelines sorted by name
+func FileInputs(spec *logging.ClusterLogForwarderSpec) []*logging.InputSpec {
+	inputs := []*logging.InputSpec{}
+	for _, input := range referencedInputs(spec) {
+		if input.File != nil {
+			inputs = append(inputs, input)
+		}
+	}
+	return inputs
+}
+
+// FileSources returns a file source for each file input
+func FileSources(spec *logging.ClusterLogForwarderSpec) []generator.Element {
+	el := []generator.Element{}
+	for _, input := range FileInputs(spec) {
+		el = append(el, source.File{
+			ComponentID:           generator.ComponentID(fmt.Sprintf(RawFileLogs, input.Name)),
+			Desc:                  fmt.Sprintf("Logs from files of the node for input %q", input.Name),
+			Includes:              input.File.Paths,
+			Excludes:              input.File.Excludes,
+			MultilineStartPattern: input.File.MultilineStartPattern,
+		})
+	}
+	return el
+}
+
+// NormalizeFileLogs classifies the records read from files and renames their fields as per the data model
+func NormalizeFileLogs(spec *logging.ClusterLogForwarderSpec) []generator.Element {
+	el := []generator.Element{}
+	for _, input := range FileInputs(spec) {
+		logType := AddLogTypeInfra
+		if input.File.LogType == logging.InputNameApplication {
+			logType = AddLogTypeApp
+		}
+		el = append(el, Remap{
+			ComponentID: FileInputID(input),
+			Inputs:      helpers.MakeInputs(fmt.Sprintf(RawFileLogs, input.Name)),
+			VRL: strings.Join(helpers.TrimSpaces([]string{
+				logType,
+				FixHostname,
+				RemoveSourceType,
+				DetectLogLevel(spec.LevelDetection),
+				FixTimestampField,
+			}), "\n"),
+		})
+	}
+	return el
+}
+
+// FileInputID returns the ID of the component emitting the records of a file input
+func FileInputID(input *logging.InputSpec) string {
+	return fmt.Sprintf(FileLogs, input.Name)
+}
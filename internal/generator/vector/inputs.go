@@ -56,17 +56,26 @@ func Inputs(spec *logging.ClusterLogForwarderSpec, o Options) []Element {
 
 	types := GatherSources(spec, o)
 	// route container_logs based on type
-	if types.Has(logging.InputNameApplication) || types.Has(logging.InputNameInfrastructure) {
+	if types.HasAny(logging.InputNameApplication, logging.InputNameInfrastructure, logging.InputNameEvents) {
 		r := Route{
 			ComponentID: "route_container_logs",
 			Inputs:      helpers.MakeInputs(InputContainerLogs),
 			Routes:      map[string]string{},
 		}
+		appLogs, infraLogs := AppContainerLogs, InfraContainerLogs
+		if types.Has(logging.InputNameEvents) {
+			// Event router logs are only forwarded by events inputs
+			appLogs = AND(appLogs, Neg(Paren(EventRouterLogs(o))))
+			infraLogs = AND(infraLogs, Neg(Paren(EventRouterLogs(o))))
+		}
 		if types.Has(logging.InputNameApplication) {
-			r.Routes["app"] = Quote(AppContainerLogs)
+			r.Routes["app"] = Quote(appLogs)
 		}
 		if types.Has(logging.InputNameInfrastructure) {
-			r.Routes["infra"] = Quote(InfraContainerLogs)
+			r.Routes["infra"] = Quote(infraLogs)
+		}
+		if types.Has(logging.InputNameEvents) {
+			r.Routes["events"] = Quote(EventRouterLogs(o))
 		}
 		el = append(el, r)
 	}
@@ -92,13 +101,18 @@ func Inputs(spec *logging.ClusterLogForwarderSpec, o Options) []Element {
 			Remap{
 				Desc:        `Set log_type to "audit"`,
 				ComponentID: logging.InputNameAudit,
-				Inputs:      helpers.MakeInputs(HostAuditLogs, K8sAuditLogs, OpenshiftAuditLogs, OvnAuditLogs),
+				Inputs:      helpers.MakeInputs(HostAuditInputID(spec), K8sAuditLogs, OpenshiftAuditLogs, OvnAuditLogs),
 				VRL: strings.Join(helpers.TrimSpaces([]string{
 					AddLogTypeAudit,
 					FixHostname,
 					FixTimestampField,
 				}), "\n"),
 			})
+		el = append(el, FilterAuditLogs(spec)...)
+	}
+
+	if types.Has(logging.InputNameEvents) {
+		el = append(el, Events(spec)...)
 	}
 
 	userDefinedAppRouteMap := UserDefinedAppRouting(spec, o)
@@ -121,27 +135,8 @@ func UserDefinedAppRouting(spec *logging.ClusterLogForwarderSpec, o Options) map
 			if input, ok := userDefined[inRef]; ok {
 				// user defined input
 				if input.Application != nil {
-					app := input.Application
-					matchNS := []string{}
-					if len(app.Namespaces) != 0 {
-						for _, ns := range app.Namespaces {
-							matchNS = append(matchNS, MatchNS(ns))
-						}
-					}
-					matchLabels := []string{}
-					if app.Selector != nil && len(app.Selector.MatchLabels) != 0 {
-						labels := app.Selector.MatchLabels
-						keys := make([]string, 0, len(labels))
-						for k := range labels {
-							keys = append(keys, k)
-						}
-						sort.Strings(keys)
-						for _, k := range keys {
-							matchLabels = append(matchLabels, MatchLabel(k, labels[k]))
-						}
-					}
-					if len(matchNS) != 0 || len(matchLabels) != 0 {
-						routeMap[input.Name] = Quote(AND(OR(matchNS...), AND(matchLabels...)))
+					if cond := appInputCondition(input.Application); cond != "" {
+						routeMap[input.Name] = Quote(cond)
 					}
 				}
 			}
@@ -149,3 +144,30 @@ func UserDefinedAppRouting(spec *logging.ClusterLogForwarderSpec, o Options) map
 	}
 	return routeMap
 }
+
+// appInputCondition returns the VRL condition matching the records of the namespaces and labels selected by an
+// application input, or "" if all records match
+func appInputCondition(app *logging.Application) string {
+	matchNS := []string{}
+	if len(app.Namespaces) != 0 {
+		for _, ns := range app.Namespaces {
+			matchNS = append(matchNS, MatchNS(ns))
+		}
+	}
+	matchLabels := []string{}
+	if app.Selector != nil && len(app.Selector.MatchLabels) != 0 {
+		labels := app.Selector.MatchLabels
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			matchLabels = append(matchLabels, MatchLabel(k, labels[k]))
+		}
+	}
+	if len(matchNS) == 0 && len(matchLabels) == 0 {
+		return ""
+	}
+	return AND(OR(matchNS...), AND(matchLabels...))
+}
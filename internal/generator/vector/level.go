@@ -0,0 +1,55 @@
+package vector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
+)
+
+// DetectLogLevel returns the VRL setting the level of records without one using the rules of the level detection,
+// FixLogLevel is the default detection
+func DetectLogLevel(spec *logging.LevelDetectionSpec) string {
+	if spec == nil {
+		return FixLogLevel
+	}
+	vrls := []string{"level = null"}
+	for _, rule := range spec.Rules {
+		switch {
+		case rule.Field != "":
+			vrls = append(vrls, fmt.Sprintf("if level == null {\n  level = %s\n}", output.FieldPath(rule.Field)))
+		case rule.Level != "":
+			vrls = append(vrls, fmt.Sprintf("if level == null && (match(.message, r'%s') ?? false) {\n  level = %q\n}", RegexLiteral(rule.Pattern), rule.Level))
+		default:
+			vrls = append(vrls, fmt.Sprintf("if level == null {\n  parsed, err = parse_regex(.message, r'%s')\n  if err == null {\n    level = parsed.level\n  }\n}", RegexLiteral(rule.Pattern)))
+		}
+	}
+	vrls = append(vrls, `level = downcase(to_string(level) ?? "")`, `if level == "" {
+  level = "default"
+}`)
+	if mapping := levelMapping(spec.Mapping); len(mapping) != 0 {
+		vrls = append(vrls, strings.Join(mapping, " else "))
+	}
+	vrls = append(vrls, ".level = level")
+	return fmt.Sprintf("if !exists(.level) {\n  %s\n}", strings.ReplaceAll(strings.Join(vrls, "\n"), "\n", "\n  "))
+}
+
+// levelMapping returns the VRL mapping detected levels in lower case, sorted by detected level
+func levelMapping(mapping map[string]string) []string {
+	levels := map[string]string{}
+	for from, to := range mapping {
+		levels[strings.ToLower(from)] = to
+	}
+	from := []string{}
+	for l := range levels {
+		from = append(from, l)
+	}
+	sort.Strings(from)
+	branches := []string{}
+	for _, l := range from {
+		branches = append(branches, fmt.Sprintf("if level == %q {\n  level = %q\n}", l, levels[l]))
+	}
+	return branches
+}
@@ -0,0 +1,80 @@
+package vector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
+)
+
+const (
+	LogMetricRecords = "log_metric_%s"
+	LogToMetrics     = "log_to_metric_%s"
+
+	// LogMetricValue is the field of the records holding the value observed by a log metric
+	LogMetricValue = "value"
+)
+
+// LogMetrics returns the elements deriving the log metrics of the forwarder from the records of their pipelines
+func LogMetrics(spec *logging.ClusterLogForwarderSpec) []generator.Element {
+	el := []generator.Element{}
+	for _, m := range spec.LogMetrics {
+		labels := LogMetricLabels(m)
+		el = append(el,
+			Remap{
+				Desc:        fmt.Sprintf("Select the records of log metric %q", m.Name),
+				ComponentID: fmt.Sprintf(LogMetricRecords, m.Name),
+				Inputs:      helpers.MakeInputs(m.PipelineRefs...),
+				VRL:         LogMetricVRL(m),
+			},
+			LogToMetric{
+				ID:     LogToMetricID(m),
+				Inputs: helpers.MakeInputs(fmt.Sprintf(LogMetricRecords, m.Name)),
+				Type:   string(m.Type),
+				Metric: m.Name,
+				Field:  LogMetricValue,
+				Tags:   labels,
+			})
+	}
+	return el
+}
+
+// LogMetricVRL returns the VRL replacing the records measured by a log metric with their value and labels
+func LogMetricVRL(m logging.LogMetricSpec) string {
+	vrls := []string{}
+	if m.Match != "" {
+		vrls = append(vrls, fmt.Sprintf("if !match(to_string(.message) ?? \"\", r'%s') {\n  abort\n}", strings.ReplaceAll(m.Match, "'", `\'`)))
+	}
+	if m.Type == logging.LogMetricTypeHistogram {
+		field := output.FieldPath(m.Field)
+		vrls = append(vrls, fmt.Sprintf("if %s == null {\n  abort\n}\nvalue, err = to_float(%s)\nif err != null {\n  abort\n}", field, field))
+	} else {
+		vrls = append(vrls, "value = 1")
+	}
+	labels := []string{}
+	for _, l := range LogMetricLabels(m) {
+		labels = append(labels, fmt.Sprintf("%q: to_string(%s) ?? \"\"", l, output.FieldPath(m.Labels[l])))
+	}
+	vrls = append(vrls, fmt.Sprintf(". = {%q: value, \"labels\": {%s}}", LogMetricValue, strings.Join(labels, ", ")))
+	return strings.Join(vrls, "\n")
+}
+
+// LogMetricLabels returns the sorted label names of a log metric
+func LogMetricLabels(m logging.LogMetricSpec) []string {
+	labels := []string{}
+	for l := range m.Labels {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// LogToMetricID returns the ID of the component emitting the samples of a log metric
+func LogToMetricID(m logging.LogMetricSpec) string {
+	return fmt.Sprintf(LogToMetrics, m.Name)
+}
@@ -72,3 +72,32 @@ source = '''
 '''
 {{end}}`
 }
+
+type LogToMetric struct {
+	ID     string
+	Inputs string
+	Type   string
+	Metric string
+	Field  string
+	Tags   []string
+}
+
+func (l LogToMetric) Name() string {
+	return "logToMetricTemplate"
+}
+
+func (l LogToMetric) Template() string {
+	return `{{define "` + l.Name() + `" -}}
+[transforms.{{.ID}}]
+type = "log_to_metric"
+inputs = {{.Inputs}}
+
+[[transforms.{{.ID}}.metrics]]
+type = "{{.Type}}"
+field = "{{.Field}}"
+name = "{{.Metric}}"
+{{- range $tag := .Tags}}
+tags.{{$tag}} = "{{"{{"}} labels.{{$tag}} {{"}}"}}"
+{{- end}}
+{{end}}`
+}
@@ -6,6 +6,7 @@ import (
 
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	"github.com/openshift/cluster-logging-operator/internal/generator"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
 )
@@ -109,6 +110,25 @@ match1 = parse_regex(.message, r'type=(?P<type>[^ ]+)') ?? {}
 envelop = {}
 envelop |= {"type": match1.type}
 
+payload = parse_regex(.message, r'msg=audit\([^)]*\):\s*(?P<payload>.*)$') ?? {}
+fields = replace(string(payload.payload) ?? "", r'\x1d', " ")
+# user space records nest their fields in a single quoted msg field
+nested = parse_regex(fields, r'\bmsg=\'(?P<fields>[^\']*)\'') ?? {}
+if exists(nested.fields) {
+  fields = replace(fields, r'\bmsg=\'[^\']*\'', "") + " " + (string(nested.fields) ?? "")
+}
+pairs = parse_regex_all(fields, r'(?P<key>[\w-]+)=(?P<value>"[^"]*"|\'[^\']*\'|\S*)') ?? []
+for_each(pairs) -> |_index, pair| {
+  key = string(pair.key) ?? ""
+  value = string(pair.value) ?? ""
+  if starts_with(value, "\"") || starts_with(value, "'") {
+    value = slice(value, 1, -1) ?? value
+  } else if includes(["acct", "cmd", "comm", "cwd", "data", "exe", "key", "name", "path", "proctitle"], key) && match(value, r'^(?:[0-9A-F]{2})+$') {
+    value = replace(decode_base16(value) ?? value, r'\x00', " ")
+  }
+  envelop = set!(envelop, [key], value)
+}
+
 match2, err = parse_regex(.message, r'msg=audit\((?P<ts_record>[^ ]+)\):')
 if err == null {
   sp = split(match2.ts_record,":")
@@ -121,6 +141,49 @@ if err == null {
 } else {
   log("could not parse host audit msg. err=" + err, rate_limit_secs: 0)
 }
+`
+	// FlattenHostAuditFields moves the fields of audit.linux to top level fields prefixed by "audit.linux."
+	FlattenHostAuditFields = `
+linux = object(del(."audit.linux")) ?? {}
+for_each(linux) -> |key, value| {
+  . = set!(., ["audit.linux." + key], value)
+}
+`
+	// NestHostAuditFields moves back the top level fields prefixed by "audit.linux." to audit.linux
+	NestHostAuditFields = `
+linux = {}
+fields = {}
+for_each(.) -> |key, value| {
+  if starts_with(key, "audit.linux.") {
+    linux = set!(linux, [slice!(key, 12)], value)
+  } else {
+    fields = set!(fields, [key], value)
+  }
+}
+. = fields
+."audit.linux" = linux
+`
+	// ParseOVNACL parses the name, verdict, severity and direction of the ACL and the protocol, addresses and ports
+	// of the flow of OVN ACL audit logs into the ovn field
+	ParseOVNACL = `
+acl, err = parse_regex(.message, r'\|acl_log\([^)]*\)\|[^|]*\|name="?(?P<name>[^",]*)"?, verdict=(?P<verdict>[^,:]+)(?:, severity=(?P<severity>[^,:]+))?(?:, direction=(?P<direction>[^,:]+))?(?:: (?P<flow>.*))?$')
+if err == null {
+  flow = string(acl.flow) ?? ""
+  fields = parse_key_value(flow, key_value_delimiter: "=", field_delimiter: ",") ?? {}
+  .ovn = compact({
+    "acl_name": acl.name,
+    "verdict": acl.verdict,
+    "severity": acl.severity,
+    "direction": acl.direction,
+    "protocol": split(flow, ",")[0],
+    "src_ip": fields.nw_src,
+    "dst_ip": fields.nw_dst
+  })
+  if exists(fields.ipv6_src) { .ovn.src_ip = fields.ipv6_src }
+  if exists(fields.ipv6_dst) { .ovn.dst_ip = fields.ipv6_dst }
+  if exists(fields.tp_src) { .ovn.src_port = to_int(fields.tp_src) ?? fields.tp_src }
+  if exists(fields.tp_dst) { .ovn.dst_port = to_int(fields.tp_dst) ?? fields.tp_dst }
+}
 `
 	HostAuditLogTag = ".linux-audit.log"
 	K8sAuditLogTag  = ".k8s-audit.log"
@@ -130,6 +193,8 @@ if err == null {
 del(.message)
 `
 	FixHostname = `.hostname = get_env_var("VECTOR_SELF_NODE_NAME") ?? ""`
+	// K8sAuditEventTimestamp keeps the time the request of an API audit event was received
+	K8sAuditEventTimestamp = `.timestamp = to_timestamp(.requestReceivedTimestamp) ?? now()`
 )
 
 var (
@@ -142,43 +207,60 @@ var (
 func NormalizeLogs(spec *logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
 	types := generator.GatherSources(spec, op)
 	var el []generator.Element = make([]generator.Element, 0)
-	if types.Has(logging.InputNameApplication) || types.Has(logging.InputNameInfrastructure) {
-		el = append(el, NormalizeContainerLogs("raw_container_logs", "container_logs")...)
+	if types.HasAny(logging.InputNameApplication, logging.InputNameInfrastructure, logging.InputNameEvents) {
+		el = append(el, NormalizeContainerLogs("raw_container_logs", "container_logs", spec)...)
 	}
 	if types.Has(logging.InputNameInfrastructure) {
-		el = append(el, NormalizeJournalLogs("raw_journal_logs", "journal_logs")...)
+		el = append(el, NormalizeJournalLogs("raw_journal_logs", "journal_logs", genhelper.InfrastructureJournal(spec))...)
 	}
 	if types.Has(logging.InputNameAudit) {
 		el = append(el, NormalizeHostAuditLogs(RawHostAuditLogs, HostAuditLogs)...)
+		el = append(el, CorrelateHostAuditLogs(spec)...)
 		el = append(el, NormalizeK8sAuditLogs(RawK8sAuditLogs, K8sAuditLogs)...)
 		el = append(el, NormalizeOpenshiftAuditLogs(RawOpenshiftAuditLogs, OpenshiftAuditLogs)...)
 		el = append(el, NormalizeOVNAuditLogs(RawOvnAuditLogs, OvnAuditLogs)...)
 	}
+	if types.Has(logging.InputNameFile) {
+		el = append(el, NormalizeFileLogs(spec)...)
+	}
+	if types.Has(logging.InputNameReceiver) {
+		el = append(el, NormalizeReceiverLogs(spec)...)
+	}
+	if types.Has(logging.InputNameApplication) {
+		el = append(el, NormalizePodFileLogs(spec, op)...)
+	}
 	return el
 }
 
-func NormalizeContainerLogs(inLabel, outLabel string) []generator.Element {
+func NormalizeContainerLogs(inLabel, outLabel string, spec *logging.ClusterLogForwarderSpec) []generator.Element {
+	vrls := []string{}
+	if spec.AllowParserAnnotations {
+		vrls = append(vrls, ParseAnnotatedMessage())
+	}
+	vrls = append(vrls, EnrichKubernetesMetadata(spec.Enrichment)...)
+	vrls = append(vrls,
+		DetectLogLevel(spec.LevelDetection),
+		RemoveSourceType,
+		RemoveStream,
+		RemovePodIPs,
+		FixTimestampField,
+	)
 	return []generator.Element{
 		Remap{
 			ComponentID: outLabel,
 			Inputs:      helpers.MakeInputs(inLabel),
-			VRL: strings.Join(helpers.TrimSpaces([]string{
-				FixLogLevel,
-				RemoveSourceType,
-				RemoveStream,
-				RemovePodIPs,
-				FixTimestampField,
-			}), "\n"),
+			VRL:         strings.Join(helpers.TrimSpaces(vrls), "\n"),
 		},
 	}
 }
 
-func NormalizeJournalLogs(inLabel, outLabel string) []generator.Element {
+func NormalizeJournalLogs(inLabel, outLabel string, filter *logging.Journal) []generator.Element {
 	return []generator.Element{
 		Remap{
 			ComponentID: outLabel,
 			Inputs:      helpers.MakeInputs(inLabel),
-			VRL: strings.Join(helpers.TrimSpaces([]string{
+			VRL: strings.Join(helpers.TrimSpaces(SkipEmpty([]string{
+				FilterJournalLogs(filter),
 				AddJournalLogTag,
 				DeleteJournalLogFields,
 				FixLogLevel,
@@ -188,11 +270,30 @@ func NormalizeJournalLogs(inLabel, outLabel string) []generator.Element {
 				SystemU,
 				AddTime,
 				FixTimestampField,
-			}), "\n\n"),
+			})), "\n\n"),
 		},
 	}
 }
 
+// FilterJournalLogs returns the VRL dropping the journal records which do not match all the priority and transport
+// conditions of a filter
+func FilterJournalLogs(filter *logging.Journal) string {
+	if filter == nil {
+		return ""
+	}
+	conds := []string{}
+	if priorities := genhelper.JournalPriorities(filter.MaxPriority); len(priorities) != 0 {
+		conds = append(conds, fmt.Sprintf("!includes(%s, .PRIORITY)", vrlObject(priorities)))
+	}
+	if transports := genhelper.JournalTransports(filter.Transports); len(transports) != 0 {
+		conds = append(conds, fmt.Sprintf("!includes(%s, ._TRANSPORT)", vrlObject(transports)))
+	}
+	if len(conds) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("if %s {\n  abort\n}", strings.Join(conds, " || "))
+}
+
 func NormalizeHostAuditLogs(inLabel, outLabel string) []generator.Element {
 	return []generator.Element{
 		Remap{
@@ -206,6 +307,50 @@ func NormalizeHostAuditLogs(inLabel, outLabel string) []generator.Element {
 	}
 }
 
+// CorrelateHostAuditLogs merges the records of a linux audit event, sharing the same serial, when enabled.
+// The fields of audit.linux are flattened before the reduce, which keeps the first value of top level fields only,
+// and nested again after it.
+func CorrelateHostAuditLogs(spec *logging.ClusterLogForwarderSpec) []generator.Element {
+	if spec.HostAudit == nil || !spec.HostAudit.Correlate {
+		return nil
+	}
+	window := spec.HostAudit.CorrelationWindow
+	if window == 0 {
+		window = 1
+	}
+	return []generator.Element{
+		Remap{
+			Desc:        "Flatten the fields of linux audit records",
+			ComponentID: HostAuditRecords,
+			Inputs:      helpers.MakeInputs(HostAuditLogs),
+			VRL:         strings.TrimSpace(FlattenHostAuditFields),
+		},
+		Reduce{
+			Desc:            "Merge the records of linux audit events",
+			ComponentID:     HostAuditReduced,
+			Inputs:          helpers.MakeInputs(HostAuditRecords),
+			GroupBy:         []string{`"audit.linux.record_id"`},
+			EndsWhen:        `."audit.linux.type" == "EOE"`,
+			ExpireAfterMs:   int(window) * 1000,
+			MergeStrategies: map[string]string{"message": "concat_newline"},
+		},
+		Remap{
+			Desc:        "Nest the fields of linux audit events",
+			ComponentID: HostAuditEvents,
+			Inputs:      helpers.MakeInputs(HostAuditReduced),
+			VRL:         strings.TrimSpace(NestHostAuditFields),
+		},
+	}
+}
+
+// HostAuditInputID returns the ID of the component emitting the normalized linux audit logs
+func HostAuditInputID(spec *logging.ClusterLogForwarderSpec) string {
+	if spec.HostAudit != nil && spec.HostAudit.Correlate {
+		return HostAuditEvents
+	}
+	return HostAuditLogs
+}
+
 func NormalizeK8sAuditLogs(inLabel, outLabel string) []generator.Element {
 	return []generator.Element{
 		Remap{
@@ -240,6 +385,7 @@ func NormalizeOVNAuditLogs(inLabel, outLabel string) []generator.Element {
 			VRL: strings.Join(helpers.TrimSpaces([]string{
 				AddOvnAuditTag,
 				FixLogLevel,
+				ParseOVNACL,
 			}), "\n"),
 		},
 	}
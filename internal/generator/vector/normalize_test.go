@@ -5,8 +5,10 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	"github.com/openshift/cluster-logging-operator/internal/generator"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	"github.com/openshift/cluster-logging-operator/test/helpers"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("Vector Config Generation", func() {
@@ -58,6 +60,186 @@ source = '''
   del(.kubernetes.pod_ips)
   ."@timestamp" = del(.timestamp)
 '''
+`,
+		}),
+		Entry("Application logs with parser annotations", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs: []string{
+							logging.InputNameApplication,
+						},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+				AllowParserAnnotations: true,
+			},
+			ExpectedConf: `
+[transforms.container_logs]
+type = "remap"
+inputs = ["raw_container_logs"]
+source = '''
+  if exists(.kubernetes.annotations) && exists(.kubernetes.container_name) {
+    parser = get(value: .kubernetes.annotations, path: ["logging.openshift.io/parser." + string!(.kubernetes.container_name)]) ?? null
+    if parser == "json" {
+      parsed, err = parse_json(.message)
+      if err == null {
+        .structured = parsed
+      }
+    } else if parser == "logfmt" {
+      parsed, err = parse_logfmt(.message)
+      if err == null {
+        .structured = parsed
+      }
+    } else if parser == "klog" {
+      parsed, err = parse_regex(.message, r'^(?P<level>[IWEF])(?P<timestamp>\d{4} \d{2}:\d{2}:\d{2}\.\d+)\s+(?P<id>\d+) (?P<file>[^:]+):(?P<line>\d+)\] (?P<message>.*)$')
+      if err == null {
+        .structured = parsed
+      }
+    }
+  }
+  if !exists(.level) {
+    .level = "default"
+    if match!(.message, r'Info|INFO|^I[0-9]+|level=info|Value:info|"level":"info"|<info>') {
+      .level = "info"
+    } else if match!(.message, r'Warning|WARN|^W[0-9]+|level=warn|Value:warn|"level":"warn"|<warn>') {
+      .level = "warn"
+    } else if match!(.message, r'Error|ERROR|^E[0-9]+|level=error|Value:error|"level":"error"|<error>') {
+      .level = "error"
+    } else if match!(.message, r'Critical|CRITICAL|^C[0-9]+|level=critical|Value:critical|"level":"critical"|<critical>') {
+      .level = "critical"
+    } else if match!(.message, r'Debug|DEBUG|^D[0-9]+|level=debug|Value:debug|"level":"debug"|<debug>') {
+      .level = "debug"
+    } else if match!(.message, r'Notice|NOTICE|^N[0-9]+|level=notice|Value:notice|"level":"notice"|<notice>') {
+      .level = "notice"
+    } else if match!(.message, r'Alert|ALERT|^A[0-9]+|level=alert|Value:alert|"level":"alert"|<alert>') {
+      .level = "alert"
+    } else if match!(.message, r'Emergency|EMERGENCY|^EM[0-9]+|level=emergency|Value:emergency|"level":"emergency"|<emergency>') {
+      .level = "emergency"
+    }
+  }
+  del(.source_type)
+  del(.stream)
+  del(.kubernetes.pod_ips)
+  ."@timestamp" = del(.timestamp)
+'''
+`,
+		}),
+		Entry("Application logs with custom level detection", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs: []string{
+							logging.InputNameApplication,
+						},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+				LevelDetection: &logging.LevelDetectionSpec{
+					Rules: []logging.LevelDetectionRule{
+						{Field: "structured.severity"},
+						{Pattern: `^(?<level>[IWEF])\d{4} `},
+						{Pattern: `\bpanic:`, Level: "critical"},
+					},
+					Mapping: map[string]string{
+						"W": "warn",
+						"e": "error",
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.container_logs]
+type = "remap"
+inputs = ["raw_container_logs"]
+source = '''
+  if !exists(.level) {
+    level = null
+    if level == null {
+      level = .structured.severity
+    }
+    if level == null {
+      parsed, err = parse_regex(.message, r'^(?P<level>[IWEF])\d{4} ')
+      if err == null {
+        level = parsed.level
+      }
+    }
+    if level == null && (match(.message, r'\bpanic:') ?? false) {
+      level = "critical"
+    }
+    level = downcase(to_string(level) ?? "")
+    if level == "" {
+      level = "default"
+    }
+    if level == "e" {
+      level = "error"
+    } else if level == "w" {
+      level = "warn"
+    }
+    .level = level
+  }
+  del(.source_type)
+  del(.stream)
+  del(.kubernetes.pod_ips)
+  ."@timestamp" = del(.timestamp)
+'''
+`,
+		}),
+		Entry("Application logs with workload and node labels enrichment", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs: []string{
+							logging.InputNameApplication,
+						},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+				Enrichment: &logging.EnrichmentSpec{
+					Workload:   true,
+					NodeLabels: []string{"topology.kubernetes.io/zone", "node.kubernetes.io/instance-type"},
+				},
+				LevelDetection: &logging.LevelDetectionSpec{
+					Rules: []logging.LevelDetectionRule{
+						{Field: "structured.severity"},
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.container_logs]
+type = "remap"
+inputs = ["raw_container_logs"]
+source = '''
+  if exists(.kubernetes.pod_owner) {
+    owner = split(to_string(del(.kubernetes.pod_owner)) ?? "", "/", limit: 2)
+    .kubernetes.workload_kind = owner[0]
+    .kubernetes.workload_name = owner[1]
+    if owner[0] == "ReplicaSet" && exists(.kubernetes.labels."pod-template-hash") {
+      .kubernetes.workload_kind = "Deployment"
+      .kubernetes.workload_name = replace(to_string(owner[1]) ?? "", r'-[a-z0-9]+$', "")
+    }
+  }
+  if exists(.kubernetes.node_labels) {
+    .kubernetes.node_labels = compact({"topology.kubernetes.io/zone": .kubernetes.node_labels."topology.kubernetes.io/zone", "node.kubernetes.io/instance-type": .kubernetes.node_labels."node.kubernetes.io/instance-type"})
+  }
+  if !exists(.level) {
+    level = null
+    if level == null {
+      level = .structured.severity
+    }
+    level = downcase(to_string(level) ?? "")
+    if level == "" {
+      level = "default"
+    }
+    .level = level
+  }
+  del(.source_type)
+  del(.stream)
+  del(.kubernetes.pod_ips)
+  ."@timestamp" = del(.timestamp)
+'''
 `,
 		}),
 		Entry("Infrastructure logs only", helpers.ConfGenerateTest{
@@ -197,6 +379,155 @@ source = '''
 '''
 
 `,
+		}),
+		Entry("Infrastructure logs with journal priority and transport filters", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "nodes",
+						Infrastructure: &logging.Infrastructure{
+							Journal: &logging.Journal{
+								IncludeUnits: []string{"kubelet"},
+								MaxPriority:  "warning",
+								Transports:   []logging.JournalTransport{logging.JournalTransportJournal, logging.JournalTransportStdout},
+							},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"nodes"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `[transforms.container_logs]
+type = "remap"
+inputs = ["raw_container_logs"]
+source = '''
+  if !exists(.level) {
+    .level = "default"
+    if match!(.message, r'Info|INFO|^I[0-9]+|level=info|Value:info|"level":"info"|<info>') {
+      .level = "info"
+    } else if match!(.message, r'Warning|WARN|^W[0-9]+|level=warn|Value:warn|"level":"warn"|<warn>') {
+      .level = "warn"
+    } else if match!(.message, r'Error|ERROR|^E[0-9]+|level=error|Value:error|"level":"error"|<error>') {
+      .level = "error"
+    } else if match!(.message, r'Critical|CRITICAL|^C[0-9]+|level=critical|Value:critical|"level":"critical"|<critical>') {
+      .level = "critical"
+    } else if match!(.message, r'Debug|DEBUG|^D[0-9]+|level=debug|Value:debug|"level":"debug"|<debug>') {
+      .level = "debug"
+    } else if match!(.message, r'Notice|NOTICE|^N[0-9]+|level=notice|Value:notice|"level":"notice"|<notice>') {
+      .level = "notice"
+    } else if match!(.message, r'Alert|ALERT|^A[0-9]+|level=alert|Value:alert|"level":"alert"|<alert>') {
+      .level = "alert"
+    } else if match!(.message, r'Emergency|EMERGENCY|^EM[0-9]+|level=emergency|Value:emergency|"level":"emergency"|<emergency>') {
+      .level = "emergency"
+    }
+  }
+  del(.source_type)
+  del(.stream)
+  del(.kubernetes.pod_ips)
+  ."@timestamp" = del(.timestamp)
+'''
+
+[transforms.journal_logs]
+type = "remap"
+inputs = ["raw_journal_logs"]
+source = '''
+  if !includes(["0","1","2","3","4"], .PRIORITY) || !includes(["journal","stdout"], ._TRANSPORT) {
+    abort
+  }
+  
+  .tag = ".journal.system"
+  
+  del(.source_type)
+  del(._CPU_USAGE_NSEC)
+  del(.__REALTIME_TIMESTAMP)
+  del(.__MONOTONIC_TIMESTAMP)
+  del(._SOURCE_REALTIME_TIMESTAMP)
+  del(.PRIORITY)
+  del(.JOB_RESULT)
+  del(.JOB_TYPE)
+  del(.TIMESTAMP_BOOTTIME)
+  del(.TIMESTAMP_MONOTONIC)
+  
+  if !exists(.level) {
+    .level = "default"
+    if match!(.message, r'Info|INFO|^I[0-9]+|level=info|Value:info|"level":"info"|<info>') {
+      .level = "info"
+    } else if match!(.message, r'Warning|WARN|^W[0-9]+|level=warn|Value:warn|"level":"warn"|<warn>') {
+      .level = "warn"
+    } else if match!(.message, r'Error|ERROR|^E[0-9]+|level=error|Value:error|"level":"error"|<error>') {
+      .level = "error"
+    } else if match!(.message, r'Critical|CRITICAL|^C[0-9]+|level=critical|Value:critical|"level":"critical"|<critical>') {
+      .level = "critical"
+    } else if match!(.message, r'Debug|DEBUG|^D[0-9]+|level=debug|Value:debug|"level":"debug"|<debug>') {
+      .level = "debug"
+    } else if match!(.message, r'Notice|NOTICE|^N[0-9]+|level=notice|Value:notice|"level":"notice"|<notice>') {
+      .level = "notice"
+    } else if match!(.message, r'Alert|ALERT|^A[0-9]+|level=alert|Value:alert|"level":"alert"|<alert>') {
+      .level = "alert"
+    } else if match!(.message, r'Emergency|EMERGENCY|^EM[0-9]+|level=emergency|Value:emergency|"level":"emergency"|<emergency>') {
+      .level = "emergency"
+    }
+  }
+  
+  .hostname = del(.host)
+  
+  # systemd’s kernel-specific metadata.
+  # .systemd.k = {}
+  if exists(.KERNEL_DEVICE) { .systemd.k.KERNEL_DEVICE = del(.KERNEL_DEVICE) }
+  if exists(.KERNEL_SUBSYSTEM) { .systemd.k.KERNEL_SUBSYSTEM = del(.KERNEL_SUBSYSTEM) }
+  if exists(.UDEV_DEVLINK) { .systemd.k.UDEV_DEVLINK = del(.UDEV_DEVLINK) }
+  if exists(.UDEV_DEVNODE) { .systemd.k.UDEV_DEVNODE = del(.UDEV_DEVNODE) }
+  if exists(.UDEV_SYSNAME) { .systemd.k.UDEV_SYSNAME = del(.UDEV_SYSNAME) }
+  
+  # trusted journal fields, fields that are implicitly added by the journal and cannot be altered by client code.
+  .systemd.t = {}
+  if exists(._AUDIT_LOGINUID) { .systemd.t.AUDIT_LOGINUID = del(._AUDIT_LOGINUID) }
+  if exists(._BOOT_ID) { .systemd.t.BOOT_ID = del(._BOOT_ID) }
+  if exists(._AUDIT_SESSION) { .systemd.t.AUDIT_SESSION = del(._AUDIT_SESSION) }
+  if exists(._CAP_EFFECTIVE) { .systemd.t.CAP_EFFECTIVE = del(._CAP_EFFECTIVE) }
+  if exists(._CMDLINE) { .systemd.t.CMDLINE = del(._CMDLINE) }
+  if exists(._COMM) { .systemd.t.COMM = del(._COMM) }
+  if exists(._EXE) { .systemd.t.EXE = del(._EXE) }
+  if exists(._GID) { .systemd.t.GID = del(._GID) }
+  if exists(._HOSTNAME) { .systemd.t.HOSTNAME = .hostname }
+  if exists(._LINE_BREAK) { .systemd.t.LINE_BREAK = del(._LINE_BREAK) }
+  if exists(._MACHINE_ID) { .systemd.t.MACHINE_ID = del(._MACHINE_ID) }
+  if exists(._PID) { .systemd.t.PID = del(._PID) }
+  if exists(._SELINUX_CONTEXT) { .systemd.t.SELINUX_CONTEXT = del(._SELINUX_CONTEXT) }
+  if exists(._SOURCE_REALTIME_TIMESTAMP) { .systemd.t.SOURCE_REALTIME_TIMESTAMP = del(._SOURCE_REALTIME_TIMESTAMP) }
+  if exists(._STREAM_ID) { .systemd.t.STREAM_ID = ._STREAM_ID }
+  if exists(._SYSTEMD_CGROUP) { .systemd.t.SYSTEMD_CGROUP = del(._SYSTEMD_CGROUP) }
+  if exists(._SYSTEMD_INVOCATION_ID) {.systemd.t.SYSTEMD_INVOCATION_ID = ._SYSTEMD_INVOCATION_ID}
+  if exists(._SYSTEMD_OWNER_UID) { .systemd.t.SYSTEMD_OWNER_UID = del(._SYSTEMD_OWNER_UID) }
+  if exists(._SYSTEMD_SESSION) { .systemd.t.SYSTEMD_SESSION = del(._SYSTEMD_SESSION) }
+  if exists(._SYSTEMD_SLICE) { .systemd.t.SYSTEMD_SLICE = del(._SYSTEMD_SLICE) }
+  if exists(._SYSTEMD_UNIT) { .systemd.t.SYSTEMD_UNIT = del(._SYSTEMD_UNIT) }
+  if exists(._SYSTEMD_USER_UNIT) { .systemd.t.SYSTEMD_USER_UNIT = del(._SYSTEMD_USER_UNIT) }
+  if exists(._TRANSPORT) { .systemd.t.TRANSPORT = del(._TRANSPORT) }
+  if exists(._UID) { .systemd.t.UID = del(._UID) }
+  
+  # fields that are directly passed from clients and stored in the journal.
+  .systemd.u = {}
+  if exists(.CODE_FILE) { .systemd.u.CODE_FILE = del(.CODE_FILE) }
+  if exists(.CODE_FUNC) { .systemd.u.CODE_FUNCTION = del(.CODE_FUNC) }
+  if exists(.CODE_LINE) { .systemd.u.CODE_LINE = del(.CODE_LINE) }
+  if exists(.ERRNO) { .systemd.u.ERRNO = del(.ERRNO) }
+  if exists(.MESSAGE_ID) { .systemd.u.MESSAGE_ID = del(.MESSAGE_ID) }
+  if exists(.SYSLOG_FACILITY) { .systemd.u.SYSLOG_FACILITY = del(.SYSLOG_FACILITY) }
+  if exists(.SYSLOG_IDENTIFIER) { .systemd.u.SYSLOG_IDENTIFIER = del(.SYSLOG_IDENTIFIER) }
+  if exists(.SYSLOG_PID) { .systemd.u.SYSLOG_PID = del(.SYSLOG_PID) }
+  if exists(.RESULT) { .systemd.u.RESULT = del(.RESULT) }
+  if exists(.UNIT) { .systemd.u.UNIT = del(.UNIT) }
+  
+  .time = format_timestamp!(.timestamp, format: "%FT%T%:z")
+  
+  ."@timestamp" = del(.timestamp)
+'''`,
 		}),
 		Entry("Audit logs only", helpers.ConfGenerateTest{
 			CLFSpec: logging.ClusterLogForwarderSpec{
@@ -221,6 +552,25 @@ source = '''
   envelop = {}
   envelop |= {"type": match1.type}
   
+  payload = parse_regex(.message, r'msg=audit\([^)]*\):\s*(?P<payload>.*)$') ?? {}
+  fields = replace(string(payload.payload) ?? "", r'\x1d', " ")
+  # user space records nest their fields in a single quoted msg field
+  nested = parse_regex(fields, r'\bmsg=\'(?P<fields>[^\']*)\'') ?? {}
+  if exists(nested.fields) {
+    fields = replace(fields, r'\bmsg=\'[^\']*\'', "") + " " + (string(nested.fields) ?? "")
+  }
+  pairs = parse_regex_all(fields, r'(?P<key>[\w-]+)=(?P<value>"[^"]*"|\'[^\']*\'|\S*)') ?? []
+  for_each(pairs) -> |_index, pair| {
+    key = string(pair.key) ?? ""
+    value = string(pair.value) ?? ""
+    if starts_with(value, "\"") || starts_with(value, "'") {
+      value = slice(value, 1, -1) ?? value
+    } else if includes(["acct", "cmd", "comm", "cwd", "data", "exe", "key", "name", "path", "proctitle"], key) && match(value, r'^(?:[0-9A-F]{2})+$') {
+      value = replace(decode_base16(value) ?? value, r'\x00', " ")
+    }
+    envelop = set!(envelop, [key], value)
+  }
+  
   match2, err = parse_regex(.message, r'msg=audit\((?P<ts_record>[^ ]+)\):')
   if err == null {
     sp = split(match2.ts_record,":")
@@ -234,7 +584,7 @@ source = '''
     log("could not parse host audit msg. err=" + err, rate_limit_secs: 0)
   }
 '''
-    
+
 [transforms.k8s_audit_logs]
 type = "remap"
 inputs = ["raw_k8s_audit_logs"]
@@ -278,6 +628,501 @@ source = '''
       .level = "emergency"
     }
   }
+  acl, err = parse_regex(.message, r'\|acl_log\([^)]*\)\|[^|]*\|name="?(?P<name>[^",]*)"?, verdict=(?P<verdict>[^,:]+)(?:, severity=(?P<severity>[^,:]+))?(?:, direction=(?P<direction>[^,:]+))?(?:: (?P<flow>.*))?$')
+  if err == null {
+    flow = string(acl.flow) ?? ""
+    fields = parse_key_value(flow, key_value_delimiter: "=", field_delimiter: ",") ?? {}
+    .ovn = compact({
+      "acl_name": acl.name,
+      "verdict": acl.verdict,
+      "severity": acl.severity,
+      "direction": acl.direction,
+      "protocol": split(flow, ",")[0],
+      "src_ip": fields.nw_src,
+      "dst_ip": fields.nw_dst
+    })
+    if exists(fields.ipv6_src) { .ovn.src_ip = fields.ipv6_src }
+    if exists(fields.ipv6_dst) { .ovn.dst_ip = fields.ipv6_dst }
+    if exists(fields.tp_src) { .ovn.src_port = to_int(fields.tp_src) ?? fields.tp_src }
+    if exists(fields.tp_dst) { .ovn.dst_port = to_int(fields.tp_dst) ?? fields.tp_dst }
+  }
+'''
+`,
+		}),
+		Entry("File input classified as application logs", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "vendor",
+						File: &logging.File{
+							Paths:                 []string{"/var/log/vendor/*.log", "/var/log/vendor/agent/*.log"},
+							Excludes:              []string{"/var/log/vendor/debug*.log"},
+							MultilineStartPattern: `^\d{4}-\d{2}-\d{2}`,
+							LogType:               logging.InputNameApplication,
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"vendor"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.file_vendor]
+type = "remap"
+inputs = ["raw_file_vendor"]
+source = '''
+  .log_type = "application"
+  .hostname = get_env_var("VECTOR_SELF_NODE_NAME") ?? ""
+  del(.source_type)
+  if !exists(.level) {
+    .level = "default"
+    if match!(.message, r'Info|INFO|^I[0-9]+|level=info|Value:info|"level":"info"|<info>') {
+      .level = "info"
+    } else if match!(.message, r'Warning|WARN|^W[0-9]+|level=warn|Value:warn|"level":"warn"|<warn>') {
+      .level = "warn"
+    } else if match!(.message, r'Error|ERROR|^E[0-9]+|level=error|Value:error|"level":"error"|<error>') {
+      .level = "error"
+    } else if match!(.message, r'Critical|CRITICAL|^C[0-9]+|level=critical|Value:critical|"level":"critical"|<critical>') {
+      .level = "critical"
+    } else if match!(.message, r'Debug|DEBUG|^D[0-9]+|level=debug|Value:debug|"level":"debug"|<debug>') {
+      .level = "debug"
+    } else if match!(.message, r'Notice|NOTICE|^N[0-9]+|level=notice|Value:notice|"level":"notice"|<notice>') {
+      .level = "notice"
+    } else if match!(.message, r'Alert|ALERT|^A[0-9]+|level=alert|Value:alert|"level":"alert"|<alert>') {
+      .level = "alert"
+    } else if match!(.message, r'Emergency|EMERGENCY|^EM[0-9]+|level=emergency|Value:emergency|"level":"emergency"|<emergency>') {
+      .level = "emergency"
+    }
+  }
+  ."@timestamp" = del(.timestamp)
+'''
+`,
+		}),
+		Entry("Annotated pod files classified as application logs", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "myapp",
+						Application: &logging.Application{
+							Namespaces:     []string{"myproject"},
+							Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "legacy"}},
+							AnnotatedFiles: true,
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"myapp"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			Options: generator.Options{
+				generator.PodFiles: []genhelper.PodFiles{
+					{
+						Namespace: "myproject",
+						Pod:       "legacy-0",
+						PodID:     "6a0f4a45-1c1e-4b4e-9d4e-0e4b5c6f7a80",
+						Container: "app",
+						Labels:    map[string]string{"app": "legacy"},
+						Globs:     []string{"/var/lib/kubelet/pods/6a0f4a45-1c1e-4b4e-9d4e-0e4b5c6f7a80/volumes/kubernetes.io~empty-dir/logs/*.log"},
+					},
+					{
+						Namespace: "other",
+						Pod:       "ignored-0",
+						PodID:     "0d7e5c1a-8f1b-4c2d-a3e4-5f6a7b8c9d01",
+						Container: "app",
+						Globs:     []string{"/var/lib/kubelet/pods/0d7e5c1a-8f1b-4c2d-a3e4-5f6a7b8c9d01/volumes/kubernetes.io~empty-dir/logs/*.log"},
+					},
+				},
+			},
+			ExpectedConf: `[transforms.container_logs]
+type = "remap"
+inputs = ["raw_container_logs"]
+source = '''
+  if !exists(.level) {
+    .level = "default"
+    if match!(.message, r'Info|INFO|^I[0-9]+|level=info|Value:info|"level":"info"|<info>') {
+      .level = "info"
+    } else if match!(.message, r'Warning|WARN|^W[0-9]+|level=warn|Value:warn|"level":"warn"|<warn>') {
+      .level = "warn"
+    } else if match!(.message, r'Error|ERROR|^E[0-9]+|level=error|Value:error|"level":"error"|<error>') {
+      .level = "error"
+    } else if match!(.message, r'Critical|CRITICAL|^C[0-9]+|level=critical|Value:critical|"level":"critical"|<critical>') {
+      .level = "critical"
+    } else if match!(.message, r'Debug|DEBUG|^D[0-9]+|level=debug|Value:debug|"level":"debug"|<debug>') {
+      .level = "debug"
+    } else if match!(.message, r'Notice|NOTICE|^N[0-9]+|level=notice|Value:notice|"level":"notice"|<notice>') {
+      .level = "notice"
+    } else if match!(.message, r'Alert|ALERT|^A[0-9]+|level=alert|Value:alert|"level":"alert"|<alert>') {
+      .level = "alert"
+    } else if match!(.message, r'Emergency|EMERGENCY|^EM[0-9]+|level=emergency|Value:emergency|"level":"emergency"|<emergency>') {
+      .level = "emergency"
+    }
+  }
+  del(.source_type)
+  del(.stream)
+  del(.kubernetes.pod_ips)
+  ."@timestamp" = del(.timestamp)
+'''
+
+[transforms.pod_files]
+type = "remap"
+inputs = ["raw_pod_files"]
+source = '''
+  .log_type = "application"
+  .hostname = get_env_var("VECTOR_SELF_NODE_NAME") ?? ""
+  del(.source_type)
+  parts = parse_regex(string(.file) ?? "", r'^/var/lib/kubelet/pods/(?P<pod>[^/]+)/volumes/kubernetes\.io~empty-dir/(?P<volume>[^/]+)/') ?? {}
+  .kubernetes = get({"6a0f4a45-1c1e-4b4e-9d4e-0e4b5c6f7a80/logs":{"container_name":"app","labels":{"app":"legacy"},"namespace_name":"myproject","pod_id":"6a0f4a45-1c1e-4b4e-9d4e-0e4b5c6f7a80","pod_name":"legacy-0"}}, [(string(parts.pod) ?? "") + "/" + (string(parts.volume) ?? "")]) ?? {}
+  if !exists(.level) {
+    .level = "default"
+    if match!(.message, r'Info|INFO|^I[0-9]+|level=info|Value:info|"level":"info"|<info>') {
+      .level = "info"
+    } else if match!(.message, r'Warning|WARN|^W[0-9]+|level=warn|Value:warn|"level":"warn"|<warn>') {
+      .level = "warn"
+    } else if match!(.message, r'Error|ERROR|^E[0-9]+|level=error|Value:error|"level":"error"|<error>') {
+      .level = "error"
+    } else if match!(.message, r'Critical|CRITICAL|^C[0-9]+|level=critical|Value:critical|"level":"critical"|<critical>') {
+      .level = "critical"
+    } else if match!(.message, r'Debug|DEBUG|^D[0-9]+|level=debug|Value:debug|"level":"debug"|<debug>') {
+      .level = "debug"
+    } else if match!(.message, r'Notice|NOTICE|^N[0-9]+|level=notice|Value:notice|"level":"notice"|<notice>') {
+      .level = "notice"
+    } else if match!(.message, r'Alert|ALERT|^A[0-9]+|level=alert|Value:alert|"level":"alert"|<alert>') {
+      .level = "alert"
+    } else if match!(.message, r'Emergency|EMERGENCY|^EM[0-9]+|level=emergency|Value:emergency|"level":"emergency"|<emergency>') {
+      .level = "emergency"
+    }
+  }
+  ."@timestamp" = del(.timestamp)
+'''
+
+[transforms.pod_files_myapp]
+type = "remap"
+inputs = ["pod_files"]
+source = '''
+  if !((.kubernetes.namespace_name == "myproject") && (.kubernetes.labels.app == "legacy")) {
+    abort
+  }
+'''`,
+		}),
+		Entry("HTTP receiver authenticating clients with certificates", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "myapp",
+						Receiver: &logging.ReceiverSpec{
+							Type:   logging.ReceiverTypeHTTP,
+							Port:   8443,
+							Secret: &logging.OutputSecretSpec{Name: "myapp-clients"},
+							HTTP:   &logging.HTTPReceiver{Format: "ndjson"},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"myapp"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			Secrets: map[string]*corev1.Secret{
+				"input/myapp": {
+					ObjectMeta: metav1.ObjectMeta{Name: "myapp-clients"},
+					Data: map[string][]byte{
+						"ca-bundle.crt": []byte("-- cert --"),
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.receiver_myapp]
+type = "remap"
+inputs = ["raw_receiver_myapp"]
+source = '''
+  .log_type = "application"
+  .input_name = "myapp"
+  .hostname = get_env_var("VECTOR_SELF_NODE_NAME") ?? ""
+  del(.source_type)
+  del(.path)
+  if !exists(.level) {
+    .level = "default"
+    if match!(.message, r'Info|INFO|^I[0-9]+|level=info|Value:info|"level":"info"|<info>') {
+      .level = "info"
+    } else if match!(.message, r'Warning|WARN|^W[0-9]+|level=warn|Value:warn|"level":"warn"|<warn>') {
+      .level = "warn"
+    } else if match!(.message, r'Error|ERROR|^E[0-9]+|level=error|Value:error|"level":"error"|<error>') {
+      .level = "error"
+    } else if match!(.message, r'Critical|CRITICAL|^C[0-9]+|level=critical|Value:critical|"level":"critical"|<critical>') {
+      .level = "critical"
+    } else if match!(.message, r'Debug|DEBUG|^D[0-9]+|level=debug|Value:debug|"level":"debug"|<debug>') {
+      .level = "debug"
+    } else if match!(.message, r'Notice|NOTICE|^N[0-9]+|level=notice|Value:notice|"level":"notice"|<notice>') {
+      .level = "notice"
+    } else if match!(.message, r'Alert|ALERT|^A[0-9]+|level=alert|Value:alert|"level":"alert"|<alert>') {
+      .level = "alert"
+    } else if match!(.message, r'Emergency|EMERGENCY|^EM[0-9]+|level=emergency|Value:emergency|"level":"emergency"|<emergency>') {
+      .level = "emergency"
+    }
+  }
+  if !exists(."@timestamp") {
+    ."@timestamp" = .timestamp
+  }
+  del(.timestamp)
+'''
+`,
+		}),
+		Entry("Syslog receiver", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "appliances",
+						Receiver: &logging.ReceiverSpec{
+							Type:   logging.ReceiverTypeSyslog,
+							Port:   5514,
+							Syslog: &logging.SyslogReceiver{Protocol: "udp"},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"appliances"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.receiver_appliances]
+type = "remap"
+inputs = ["raw_receiver_appliances"]
+source = '''
+  .log_type = "infrastructure"
+  .input_name = "appliances"
+  if !exists(.hostname) {
+    .hostname = .host
+  }
+  del(.host)
+  del(.source_type)
+  del(.source_ip)
+  del(.version)
+  severity = to_string(del(.severity)) ?? ""
+  .level = "default"
+  if severity == "emerg" {
+    .level = "emergency"
+  } else if severity == "crit" {
+    .level = "critical"
+  } else if severity == "err" {
+    .level = "error"
+  } else if severity == "warning" {
+    .level = "warn"
+  } else if includes(["alert", "notice", "info", "debug"], severity) {
+    .level = severity
+  }
+  .systemd.u = {}
+  if exists(.facility) { .systemd.u.SYSLOG_FACILITY = del(.facility) }
+  if exists(.appname) { .systemd.u.SYSLOG_IDENTIFIER = del(.appname) }
+  if exists(.procid) { .systemd.u.SYSLOG_PID = del(.procid) }
+  if exists(.msgid) { .systemd.u.MESSAGE_ID = del(.msgid) }
+  ."@timestamp" = del(.timestamp)
+'''
+`,
+		}),
+		Entry("Kubernetes API audit webhook receiver", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "hosted-audit",
+						Receiver: &logging.ReceiverSpec{
+							Type:   logging.ReceiverTypeKubeAPIAudit,
+							Port:   9443,
+							Secret: &logging.OutputSecretSpec{Name: "apiserver"},
+							KubeAPIAudit: &logging.KubeAPIAuditReceiver{
+								Filter: &logging.AuditFilter{
+									Rules: []logging.AuditRule{{Level: logging.AuditLevelMetadata}},
+								},
+							},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"hosted-audit"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			Secrets: map[string]*corev1.Secret{
+				"input/hosted-audit": {
+					ObjectMeta: metav1.ObjectMeta{Name: "apiserver"},
+					Data:       map[string][]byte{"ca-bundle.crt": []byte("-- cert --")},
+				},
+			},
+			ExpectedConf: `
+[transforms.receiver_hosted-audit_events]
+type = "remap"
+inputs = ["raw_receiver_hosted-audit"]
+source = '''
+  if .kind != "EventList" {
+    abort
+  }
+  . = array(.items) ?? []
+'''
+
+[transforms.receiver_hosted-audit]
+type = "remap"
+inputs = ["receiver_hosted-audit_events"]
+source = '''
+  .tag = ".k8s-audit.log"
+  .timestamp = to_timestamp(.requestReceivedTimestamp) ?? now()
+  .log_type = "audit"
+  .input_name = "hosted-audit"
+  .hostname = get_env_var("VECTOR_SELF_NODE_NAME") ?? ""
+  ."@timestamp" = del(.timestamp)
+'''
+
+# Filter API audit events of input "hosted-audit"
+[transforms.receiver_hosted-audit_filtered]
+type = "remap"
+inputs = ["receiver_hosted-audit"]
+source = '''
+  if .tag == ".k8s-audit.log" || .tag == ".openshift-audit.log" {
+    stage = string(.stage) ?? ""
+    verb = string(.verb) ?? ""
+    user = string(.user.username) ?? ""
+    namespace = string(.objectRef.namespace) ?? ""
+    group = string(.objectRef.apiGroup) ?? ""
+    resource = string(.objectRef.resource) ?? ""
+    subresource = string(.objectRef.subresource) ?? ""
+    if resource != "" && subresource != "" {
+      resource = resource + "/" + subresource
+    }
+    level = "None"
+    omit = []
+    if true {
+      level = "Metadata"
+      omit = []
+    }
+    omit = append(omit, [])
+    if includes(omit, stage) || level == "None" {
+      abort
+    }
+    if level == "Metadata" {
+      del(.requestObject)
+      del(.responseObject)
+    } else if level == "Request" {
+      del(.responseObject)
+    }
+  }
+'''
+`,
+		}),
+	)
+})
+
+var _ = Describe("Vector Config Generation", func() {
+	var f = func(clspec logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
+		return CorrelateHostAuditLogs(&clfspec)
+	}
+	DescribeTable("CorrelateHostAuditLogs", helpers.TestGenerateConfWith(f),
+		Entry("without correlation", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				HostAudit: &logging.HostAuditSpec{},
+			},
+			ExpectedConf: ``,
+		}),
+		Entry("with correlation in the default window", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				HostAudit: &logging.HostAuditSpec{Correlate: true},
+			},
+			ExpectedConf: `
+# Flatten the fields of linux audit records
+[transforms.host_audit_records]
+type = "remap"
+inputs = ["host_audit_logs"]
+source = '''
+  linux = object(del(."audit.linux")) ?? {}
+  for_each(linux) -> |key, value| {
+    . = set!(., ["audit.linux." + key], value)
+  }
+'''
+
+# Merge the records of linux audit events
+[transforms.host_audit_reduced]
+type = "reduce"
+inputs = ["host_audit_records"]
+group_by = ["\"audit.linux.record_id\""]
+ends_when = '."audit.linux.type" == "EOE"'
+expire_after_ms = 1000
+merge_strategies.message = "concat_newline"
+
+# Nest the fields of linux audit events
+[transforms.host_audit_events]
+type = "remap"
+inputs = ["host_audit_reduced"]
+source = '''
+  linux = {}
+  fields = {}
+  for_each(.) -> |key, value| {
+    if starts_with(key, "audit.linux.") {
+      linux = set!(linux, [slice!(key, 12)], value)
+    } else {
+      fields = set!(fields, [key], value)
+    }
+  }
+  . = fields
+  ."audit.linux" = linux
+'''
+`,
+		}),
+		Entry("with correlation in a custom window", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				HostAudit: &logging.HostAuditSpec{Correlate: true, CorrelationWindow: 5},
+			},
+			ExpectedConf: `
+# Flatten the fields of linux audit records
+[transforms.host_audit_records]
+type = "remap"
+inputs = ["host_audit_logs"]
+source = '''
+  linux = object(del(."audit.linux")) ?? {}
+  for_each(linux) -> |key, value| {
+    . = set!(., ["audit.linux." + key], value)
+  }
+'''
+
+# Merge the records of linux audit events
+[transforms.host_audit_reduced]
+type = "reduce"
+inputs = ["host_audit_records"]
+group_by = ["\"audit.linux.record_id\""]
+ends_when = '."audit.linux.type" == "EOE"'
+expire_after_ms = 5000
+merge_strategies.message = "concat_newline"
+
+# Nest the fields of linux audit events
+[transforms.host_audit_events]
+type = "remap"
+inputs = ["host_audit_reduced"]
+source = '''
+  linux = {}
+  fields = {}
+  for_each(.) -> |key, value| {
+    if starts_with(key, "audit.linux.") {
+      linux = set!(linux, [slice!(key, 12)], value)
+    } else {
+      fields = set!(fields, [key], value)
+    }
+  }
+  . = fields
+  ."audit.linux" = linux
 '''
 `,
 		}),
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"fmt"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	. "github.com/openshift/cluster-logging-operator/internal/generator"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
+)
+
+const (
+	// DefaultDiskBufferMaxSize is the maximum size of a disk buffer if not specified, 1Gi
+	DefaultDiskBufferMaxSize int64 = 1073741824
+)
+
+type Buffer struct {
+	ComponentID string
+	Type        logging.OutputBufferType
+	MaxSize     Element
+	WhenFull    logging.BufferWhenFullType
+}
+
+func (b Buffer) Name() string {
+	return "vectorBufferTemplate"
+}
+
+func (b Buffer) Template() string {
+	return `{{define "` + b.Name() + `" -}}
+[sinks.{{.ComponentID}}.buffer]
+type = "{{.Type}}"
+{{optional .MaxSize -}}
+when_full = "{{.WhenFull}}"
+{{end}}`
+}
+
+// NewBuffer returns the buffer of the sink with id if it is configured for the output
+func NewBuffer(id string, o logging.OutputSpec) []Element {
+	if o.Tuning == nil || o.Tuning.Buffer == nil {
+		return []Element{}
+	}
+	spec := o.Tuning.Buffer
+	b := Buffer{
+		ComponentID: id,
+		Type:        logging.OutputBufferTypeMemory,
+		MaxSize:     Nil,
+		WhenFull:    logging.BufferWhenFullBlock,
+	}
+	if spec.WhenFull != "" {
+		b.WhenFull = spec.WhenFull
+	}
+	if spec.Type == logging.OutputBufferTypeDisk {
+		b.Type = logging.OutputBufferTypeDisk
+		maxSize := DefaultDiskBufferMaxSize
+		if spec.MaxSize > 0 {
+			maxSize = spec.MaxSize
+		}
+		b.MaxSize = KV("max_size", fmt.Sprintf("%d", maxSize))
+	}
+	return []Element{b}
+}
@@ -7,6 +7,7 @@ import (
 	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output/security"
 	corev1 "k8s.io/api/core/v1"
 	"strings"
@@ -37,6 +38,8 @@ type CloudWatch struct {
 	Region         string
 	EndpointConfig Element
 	SecurityConfig Element
+	Compression    Element
+	Tuning         Element
 }
 
 func (e CloudWatch) Name() string {
@@ -53,12 +56,12 @@ func (e CloudWatch) Template() string {
 type = "aws_cloudwatch_logs"
 inputs = {{.Inputs}}
 region = "{{.Region}}"
-compression = "none"
+{{kv .Compression -}}
 group_name = "{{"{{ group_name }}"}}"
 stream_name = "{{"{{ stream_name }}"}}"
 {{compose_one .SecurityConfig}}
 encoding.codec = "json"
-request.concurrency = 2
+{{kv .Tuning -}}
 {{compose_one .EndpointConfig}}
 {{- end}}
 `
@@ -73,13 +76,20 @@ func Conf(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Optio
 			Debug(outputName, helpers.MakeInputs([]string{componentID}...)),
 		}
 	}
-	return []Element{
-		NormalizeGroupAndStreamName(LogGroupNameField(o), LogGroupPrefix(o), componentID, inputs),
-		OutputConf(o, []string{componentID}, secret, op, o.Cloudwatch.Region),
-	}
+	return MergeElements(
+		[]Element{
+			NormalizeGroupAndStreamName(LogGroupNameField(o), LogGroupPrefix(o), componentID, inputs),
+			OutputConf(o, []string{componentID}, secret, op, o.Cloudwatch.Region),
+		},
+		output.NewBuffer(outputName, o),
+	)
 }
 
 func OutputConf(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Options, region string) Element {
+	tuning := output.NewTuning(o)
+	if tuning.Concurrency == Nil {
+		tuning.Concurrency = KV("request.concurrency", "2")
+	}
 	return CloudWatch{
 		Desc:           "Cloudwatch Logs",
 		ComponentID:    helpers.FormatComponentID(o.Name),
@@ -87,6 +97,8 @@ func OutputConf(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op
 		Region:         region,
 		SecurityConfig: SecurityConfig(secret),
 		EndpointConfig: EndpointConfig(o),
+		Compression:    output.Compression(o, output.CompressionNone),
+		Tuning:         tuning,
 	}
 }
 
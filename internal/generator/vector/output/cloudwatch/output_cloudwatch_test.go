@@ -261,6 +261,37 @@ tls.verify_certificate = false
 			Expect(results).To(EqualTrimLines(expConf))
 		})
 	})
+
+	Context("with tuning", func() {
+		It("should override the default compression and concurrency", func() {
+			tuned := output
+			tuned.URL = ""
+			tuned.Tuning = &loggingv1.OutputTuningSpec{
+				MaxBatchEvents: 1000,
+				Concurrency:    8,
+				Compression:    "gzip",
+			}
+			expConf := `
+# Cloudwatch Logs
+[sinks.cw]
+type = "aws_cloudwatch_logs"
+inputs = ["cw_normalize_group_and_streams"]
+region = "us-east-test"
+compression = "gzip"
+group_name = "{{ group_name }}"
+stream_name = "{{ stream_name }}"
+auth.access_key_id = "` + keyId + `"
+auth.secret_access_key = "` + keySecret + `"
+encoding.codec = "json"
+batch.max_events = 1000
+request.concurrency = 8
+`
+			element := OutputConf(tuned, []string{"cw_normalize_group_and_streams"}, secrets[output.Secret.Name], nil, "us-east-test")
+			results, err := g.GenerateConf(element)
+			Expect(err).To(BeNil())
+			Expect(results).To(EqualTrimLines(expConf))
+		})
+	})
 })
 
 func TestVectorConfGenerator(t *testing.T) {
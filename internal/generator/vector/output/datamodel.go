@@ -0,0 +1,48 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	. "github.com/openshift/cluster-logging-operator/internal/generator"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+)
+
+var identifier = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// DataModelID is the ID of the transform translating records to the data model of the output
+func DataModelID(o logging.OutputSpec) string {
+	return fmt.Sprintf("%s_data_model", helpers.FormatComponentID(o.Name))
+}
+
+// DataModel translates records from the ViaQ data model to the data model of the output
+func DataModel(o logging.OutputSpec, inputs []string) Element {
+	vrls := []string{}
+	for _, m := range genhelper.DataModelMoves(o) {
+		vrls = append(vrls, fmt.Sprintf("if exists(%[1]s) {\n  %[2]s = del(%[1]s)\n}", FieldPath(m.From), FieldPath(m.To)))
+	}
+	for _, v := range genhelper.DataModelValues(o) {
+		vrls = append(vrls, fmt.Sprintf("%s = %q", FieldPath(v.Path), v.Value))
+	}
+	return Remap{
+		Desc:        fmt.Sprintf("Translate records to the %s data model", o.DataModel),
+		ComponentID: DataModelID(o),
+		Inputs:      helpers.MakeInputs(inputs...),
+		VRL:         strings.Join(vrls, "\n"),
+	}
+}
+
+// FieldPath returns the VRL path of a dotted field name, quoting segments which are not identifiers
+func FieldPath(field string) string {
+	segments := strings.Split(strings.TrimPrefix(field, "."), ".")
+	for i, s := range segments {
+		if !identifier.MatchString(s) {
+			segments[i] = fmt.Sprintf("%q", s)
+		}
+	}
+	return "." + strings.Join(segments, ".")
+}
@@ -10,6 +10,7 @@ import (
 	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output/security"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -21,6 +22,8 @@ type Elasticsearch struct {
 	Inputs      string
 	Index       string
 	Endpoint    string
+	Compression Element
+	Tuning      Element
 }
 
 func (e Elasticsearch) Name() string {
@@ -35,7 +38,8 @@ inputs = {{.Inputs}}
 endpoint = "{{.Endpoint}}"
 bulk.index = "{{ "{{ write_index }}" }}"
 bulk.action = "create"
-request.timeout_secs = 2147483648
+{{kv .Compression -}}
+{{kv .Tuning -}}
 id_key = "_id"
 {{end}}`
 }
@@ -177,7 +181,6 @@ func ID(id1, id2 string) string {
 }
 
 func Conf(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Options) []Element {
-	outputs := []Element{}
 	outputName := helpers.FormatComponentID(o.Name)
 	if genhelper.IsDebugOutput(op) {
 		return []Element{
@@ -186,14 +189,22 @@ func Conf(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Optio
 			Debug(outputName, helpers.MakeInputs([]string{ID(outputName, "dedot_and_flatten")}...)),
 		}
 	}
+	outputs := []Element{
+		SetESIndex(ID(outputName, "add_es_index"), inputs, o, op),
+		FlattenLabels(ID(outputName, "dedot_and_flatten"), []string{ID(outputName, "add_es_index")}),
+	}
+	sinkInputs := []string{ID(outputName, "dedot_and_flatten")}
+	if genhelper.HasDataModel(o) {
+		outputs = append(outputs, output.DataModel(o, sinkInputs))
+		sinkInputs = []string{output.DataModelID(o)}
+	}
 	outputs = MergeElements(outputs,
 		[]Element{
-			SetESIndex(ID(outputName, "add_es_index"), inputs, o, op),
-			FlattenLabels(ID(outputName, "dedot_and_flatten"), []string{ID(outputName, "add_es_index")}),
-			Output(o, []string{ID(outputName, "dedot_and_flatten")}, secret, op),
+			Output(o, sinkInputs, secret, op),
 		},
 		TLSConf(o, secret),
 		BasicAuth(o, secret),
+		output.NewBuffer(outputName, o),
 	)
 
 	return outputs
@@ -201,10 +212,17 @@ func Conf(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Optio
 
 func Output(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Options) Element {
 
+	tuning := output.NewTuning(o)
+	if tuning.RequestTimeout == Nil {
+		// 2 ^ 31
+		tuning.RequestTimeout = KV("request.timeout_secs", "2147483648")
+	}
 	return Elasticsearch{
 		ComponentID: helpers.FormatComponentID(o.Name),
 		Endpoint:    o.URL,
 		Inputs:      helpers.MakeInputs(inputs...),
+		Compression: output.Compression(o, ""),
+		Tuning:      tuning,
 	}
 }
 
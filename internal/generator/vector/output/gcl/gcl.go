@@ -2,6 +2,8 @@ package gcl
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
@@ -10,6 +12,7 @@ import (
 	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
 	vectorhelpers "github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output/security"
 
 	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
@@ -22,9 +25,52 @@ const (
 	BillingAccountID = "billing_account_id"
 	FolderID         = "folder_id"
 
-	DefaultSeverityKey = "level"
+	// DefaultSeverityKey is the field holding the mapped severity, which the sink removes from the published payload
+	DefaultSeverityKey = "gcl_severity"
+
+	// DefaultLocation is the location of the monitored resources when the location of the cluster is unknown
+	DefaultLocation = "global"
 
 	GoogleApplicationCredentialsKey = "google-application-credentials.json"
+
+	ResourceTypeContainer = "k8s_container"
+	ResourceTypeNode      = "k8s_node"
+
+	routeContainer = "container"
+	routeNode      = "node"
+)
+
+var (
+	// logIDFieldRef matches record field references, e.g. {.kubernetes.namespace_name}, in a log ID
+	logIDFieldRef = regexp.MustCompile(`\{\.([a-zA-Z0-9_\-]+(\.[a-zA-Z0-9_\-]+)*)\}`)
+	// logIDChars are the characters allowed by Cloud Logging in a log ID
+	logIDChars = regexp.MustCompile(`^[a-zA-Z0-9_\-./]*$`)
+
+	// MapSeverity maps the normalized 'level' of a record to a Cloud Logging severity
+	MapSeverity = `
+level = downcase(to_string(.level) ?? "default")
+severity = "DEFAULT"
+if level == "trace" || level == "debug" {
+  severity = "DEBUG"
+} else if level == "info" || level == "information" {
+  severity = "INFO"
+} else if level == "notice" {
+  severity = "NOTICE"
+} else if level == "warn" || level == "warning" {
+  severity = "WARNING"
+} else if level == "error" || level == "err" {
+  severity = "ERROR"
+} else if level == "critical" || level == "crit" || level == "fatal" {
+  severity = "CRITICAL"
+} else if level == "alert" {
+  severity = "ALERT"
+} else if level == "emergency" || level == "emerg" || level == "panic" {
+  severity = "EMERGENCY"
+}
+.` + DefaultSeverityKey + ` = severity
+`
+	// IsContainerLog is true for records which carry the labels required by the k8s_container resource
+	IsContainerLog = `exists(.kubernetes.namespace_name) && exists(.kubernetes.pod_name) && exists(.kubernetes.container_name)`
 )
 
 type GoogleCloudLogging struct {
@@ -38,6 +84,16 @@ type GoogleCloudLogging struct {
 	SeverityKey string
 
 	CredentialsPath string
+
+	Tuning Element
+
+	ResourceType   string
+	ResourceLabels []Label
+}
+
+type Label struct {
+	Name  string
+	Value string
 }
 
 func (g GoogleCloudLogging) Name() string {
@@ -53,11 +109,14 @@ inputs = {{.Inputs}}
 credentials_path = {{.CredentialsPath}}
 log_id = "{{.LogID}}"
 severity_key = "{{.SeverityKey}}"
+{{kv .Tuning -}}
 
 
 [sinks.{{.ComponentID}}.resource]
-type = "k8s_node"
-node_name = "{{"{{hostname}}"}}"
+type = "{{.ResourceType}}"
+{{- range $i, $label := .ResourceLabels}}
+{{$label.Name}} = "{{$label.Value}}"
+{{- end}}
 {{end}}`
 }
 
@@ -70,16 +129,59 @@ func Conf(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Optio
 	if o.GoogleCloudLogging == nil {
 		return []Element{}
 	}
+	outputName := helpers.FormatComponentID(o.Name)
+	normalizeID := ID(outputName, "normalize_severity")
+	routeID := ID(outputName, "route_resource")
+	return MergeElements(
+		[]Element{
+			NormalizeSeverity(normalizeID, inputs),
+			RouteResource(routeID, []string{normalizeID}),
+			Output(o, ID(outputName, routeContainer), []string{routeID + "." + routeContainer}, ResourceTypeContainer, op),
+		},
+		output.NewBuffer(ID(outputName, routeContainer), o),
+		[]Element{
+			Output(o, ID(outputName, routeNode), []string{routeID + "." + routeNode}, ResourceTypeNode, op),
+		},
+		output.NewBuffer(ID(outputName, routeNode), o),
+	)
+}
+
+// NormalizeSeverity adds the Cloud Logging severity of a record from its normalized 'level'
+func NormalizeSeverity(id string, inputs []string) Element {
+	return Remap{
+		Desc:        "Map log level to Google Cloud Logging severity",
+		ComponentID: id,
+		Inputs:      helpers.MakeInputs(inputs...),
+		VRL:         strings.TrimSpace(MapSeverity),
+	}
+}
+
+// RouteResource splits records by the monitored resource type they are published with
+func RouteResource(id string, inputs []string) Element {
+	return Route{
+		Desc:        "Route records by Google Cloud Logging monitored resource type",
+		ComponentID: id,
+		Inputs:      helpers.MakeInputs(inputs...),
+		Routes: map[string]string{
+			routeContainer: fmt.Sprintf("'%s'", IsContainerLog),
+			routeNode:      fmt.Sprintf("'!(%s)'", IsContainerLog),
+		},
+	}
+}
+
+func Output(o logging.OutputSpec, id string, inputs []string, resourceType string, op Options) Element {
 	g := o.GoogleCloudLogging
-	gcl := GoogleCloudLogging{
-		ComponentID:     helpers.FormatComponentID(o.Name),
+	return GoogleCloudLogging{
+		ComponentID:     id,
 		Inputs:          helpers.MakeInputs(inputs...),
 		LogDestination:  LogDestination(g),
-		LogID:           g.LogID,
+		LogID:           LogID(g),
 		SeverityKey:     SeverityKey(g),
 		CredentialsPath: security.SecretPath(o.Secret.Name, GoogleApplicationCredentialsKey),
+		ResourceType:    resourceType,
+		ResourceLabels:  ResourceLabels(g, resourceType, op),
+		Tuning:          output.NewTuning(o),
 	}
-	return []Element{gcl}
 }
 
 // LogDestination is one of BillingAccountID, OrganizationID, FolderID, or ProjectID in that order
@@ -102,3 +204,59 @@ func LogDestination(g *logging.GoogleCloudLogging) Element {
 func SeverityKey(g *logging.GoogleCloudLogging) string {
 	return DefaultSeverityKey
 }
+
+// LogID translates record field references in the log ID to vector template syntax
+func LogID(g *logging.GoogleCloudLogging) string {
+	return logIDFieldRef.ReplaceAllString(g.LogID, "{{$1}}")
+}
+
+// IsValidLogID returns true if the log ID only has characters allowed by Cloud Logging
+// besides well-formed record field references
+func IsValidLogID(logID string) bool {
+	return logIDChars.MatchString(logIDFieldRef.ReplaceAllString(logID, ""))
+}
+
+// ResourceLabels are the labels of the monitored resource, sorted by name
+func ResourceLabels(g *logging.GoogleCloudLogging, resourceType string, op Options) []Label {
+	labels := map[string]string{}
+	if g.ProjectID != "" {
+		labels["project_id"] = g.ProjectID
+	}
+	if clusterName, ok := op[ClusterName]; ok && clusterName != "" {
+		labels["cluster_name"] = fmt.Sprintf("%v", clusterName)
+	}
+	labels["location"] = Location(g, op)
+	switch resourceType {
+	case ResourceTypeContainer:
+		labels["namespace_name"] = "{{kubernetes.namespace_name}}"
+		labels["pod_name"] = "{{kubernetes.pod_name}}"
+		labels["container_name"] = "{{kubernetes.container_name}}"
+	case ResourceTypeNode:
+		labels["node_name"] = "{{hostname}}"
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]Label, 0, len(names))
+	for _, name := range names {
+		result = append(result, Label{Name: name, Value: labels[name]})
+	}
+	return result
+}
+
+// Location is the location of the output, or the location of the cluster, or DefaultLocation
+func Location(g *logging.GoogleCloudLogging, op Options) string {
+	if g.Location != "" {
+		return g.Location
+	}
+	if location, ok := op[ClusterLocation]; ok && location != "" {
+		return fmt.Sprintf("%v", location)
+	}
+	return DefaultLocation
+}
+
+func ID(id1, id2 string) string {
+	return fmt.Sprintf("%s_%s", id1, id2)
+}
@@ -49,23 +49,188 @@ var _ = Describe("Generate Vector config", func() {
 				},
 			},
 			ExpectedConf: `
-[sinks.gcl_1]
-type = "gcp_stackdriver_logs"
+# Map log level to Google Cloud Logging severity
+[transforms.gcl_1_normalize_severity]
+type = "remap"
 inputs = ["application"]
+source = '''
+  level = downcase(to_string(.level) ?? "default")
+  severity = "DEFAULT"
+  if level == "trace" || level == "debug" {
+    severity = "DEBUG"
+  } else if level == "info" || level == "information" {
+    severity = "INFO"
+  } else if level == "notice" {
+    severity = "NOTICE"
+  } else if level == "warn" || level == "warning" {
+    severity = "WARNING"
+  } else if level == "error" || level == "err" {
+    severity = "ERROR"
+  } else if level == "critical" || level == "crit" || level == "fatal" {
+    severity = "CRITICAL"
+  } else if level == "alert" {
+    severity = "ALERT"
+  } else if level == "emergency" || level == "emerg" || level == "panic" {
+    severity = "EMERGENCY"
+  }
+  .gcl_severity = severity
+'''
+
+# Route records by Google Cloud Logging monitored resource type
+[transforms.gcl_1_route_resource]
+type = "route"
+inputs = ["gcl_1_normalize_severity"]
+route.container = 'exists(.kubernetes.namespace_name) && exists(.kubernetes.pod_name) && exists(.kubernetes.container_name)'
+route.node = '!(exists(.kubernetes.namespace_name) && exists(.kubernetes.pod_name) && exists(.kubernetes.container_name))'
+
+[sinks.gcl_1_container]
+type = "gcp_stackdriver_logs"
+inputs = ["gcl_1_route_resource.container"]
 billing_account_id = "billing-1"
 credentials_path = "/var/run/ocp-collector/secrets/junk/google-application-credentials.json"
 log_id = "vector-1"
-severity_key = "level"
+severity_key = "gcl_severity"
+
+
+[sinks.gcl_1_container.resource]
+type = "k8s_container"
+container_name = "{{kubernetes.container_name}}"
+location = "global"
+namespace_name = "{{kubernetes.namespace_name}}"
+pod_name = "{{kubernetes.pod_name}}"
+
+[sinks.gcl_1_node]
+type = "gcp_stackdriver_logs"
+inputs = ["gcl_1_route_resource.node"]
+billing_account_id = "billing-1"
+credentials_path = "/var/run/ocp-collector/secrets/junk/google-application-credentials.json"
+log_id = "vector-1"
+severity_key = "gcl_severity"
+
+
+[sinks.gcl_1_node.resource]
+type = "k8s_node"
+location = "global"
+node_name = "{{hostname}}"
+`,
+		}),
+		Entry("with project id, cluster name, cluster location and templated log id", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Outputs: []logging.OutputSpec{
+					{
+						Type: logging.OutputTypeGoogleCloudLogging,
+						Name: "gcl-1",
+						OutputTypeSpec: logging.OutputTypeSpec{
+							GoogleCloudLogging: &logging.GoogleCloudLogging{
+								ProjectID: "project-1",
+								LogID:     "app-{.kubernetes.namespace_name}",
+							},
+						},
+						Secret: &logging.OutputSecretSpec{
+							Name: "junk",
+						},
+					},
+				},
+			},
+			Options: generator.Options{
+				generator.ClusterName:     "cluster-1",
+				generator.ClusterLocation: "us-central1",
+			},
+			ExpectedConf: `
+# Map log level to Google Cloud Logging severity
+[transforms.gcl_1_normalize_severity]
+type = "remap"
+inputs = ["application"]
+source = '''
+  level = downcase(to_string(.level) ?? "default")
+  severity = "DEFAULT"
+  if level == "trace" || level == "debug" {
+    severity = "DEBUG"
+  } else if level == "info" || level == "information" {
+    severity = "INFO"
+  } else if level == "notice" {
+    severity = "NOTICE"
+  } else if level == "warn" || level == "warning" {
+    severity = "WARNING"
+  } else if level == "error" || level == "err" {
+    severity = "ERROR"
+  } else if level == "critical" || level == "crit" || level == "fatal" {
+    severity = "CRITICAL"
+  } else if level == "alert" {
+    severity = "ALERT"
+  } else if level == "emergency" || level == "emerg" || level == "panic" {
+    severity = "EMERGENCY"
+  }
+  .gcl_severity = severity
+'''
 
+# Route records by Google Cloud Logging monitored resource type
+[transforms.gcl_1_route_resource]
+type = "route"
+inputs = ["gcl_1_normalize_severity"]
+route.container = 'exists(.kubernetes.namespace_name) && exists(.kubernetes.pod_name) && exists(.kubernetes.container_name)'
+route.node = '!(exists(.kubernetes.namespace_name) && exists(.kubernetes.pod_name) && exists(.kubernetes.container_name))'
+
+[sinks.gcl_1_container]
+type = "gcp_stackdriver_logs"
+inputs = ["gcl_1_route_resource.container"]
+project_id = "project-1"
+credentials_path = "/var/run/ocp-collector/secrets/junk/google-application-credentials.json"
+log_id = "app-{{kubernetes.namespace_name}}"
+severity_key = "gcl_severity"
 
-[sinks.gcl_1.resource]
+
+[sinks.gcl_1_container.resource]
+type = "k8s_container"
+cluster_name = "cluster-1"
+container_name = "{{kubernetes.container_name}}"
+location = "us-central1"
+namespace_name = "{{kubernetes.namespace_name}}"
+pod_name = "{{kubernetes.pod_name}}"
+project_id = "project-1"
+
+[sinks.gcl_1_node]
+type = "gcp_stackdriver_logs"
+inputs = ["gcl_1_route_resource.node"]
+project_id = "project-1"
+credentials_path = "/var/run/ocp-collector/secrets/junk/google-application-credentials.json"
+log_id = "app-{{kubernetes.namespace_name}}"
+severity_key = "gcl_severity"
+
+
+[sinks.gcl_1_node.resource]
 type = "k8s_node"
+cluster_name = "cluster-1"
+location = "us-central1"
 node_name = "{{hostname}}"
+project_id = "project-1"
 `,
 		}),
 	)
 })
 
+var _ = Describe("LogID", func() {
+	DescribeTable("#IsValidLogID", func(logID string, valid bool) {
+		Expect(IsValidLogID(logID)).To(Equal(valid))
+	},
+		Entry("with literal ID", "my-log_1.2/3", true),
+		Entry("with record field references", "app-{.kubernetes.namespace_name}.{.log_type}", true),
+		Entry("with unsupported characters", "my log", false),
+		Entry("with malformed field reference", "app-{kubernetes.namespace_name}", false),
+		Entry("with unterminated field reference", "app-{.kubernetes.namespace_name", false),
+	)
+})
+
+var _ = Describe("Location", func() {
+	DescribeTable("#Location", func(location string, op generator.Options, expected string) {
+		Expect(Location(&logging.GoogleCloudLogging{Location: location}, op)).To(Equal(expected))
+	},
+		Entry("with output location", "europe-west1", generator.Options{generator.ClusterLocation: "us-central1"}, "europe-west1"),
+		Entry("with cluster location", "", generator.Options{generator.ClusterLocation: "us-central1"}, "us-central1"),
+		Entry("without location", "", generator.Options{}, DefaultLocation),
+	)
+})
+
 func TestVectorConfGenerator(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Vector Conf Generation")
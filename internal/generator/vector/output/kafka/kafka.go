@@ -14,6 +14,7 @@ import (
 	urlhelper "github.com/openshift/cluster-logging-operator/internal/generator/url"
 	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
 	vectorhelpers "github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output/security"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -28,6 +29,8 @@ type Kafka struct {
 	Inputs           string
 	BootstrapServers string
 	Topic            string
+	Compression      Element
+	Tuning           Element
 }
 
 func (k Kafka) Name() string {
@@ -42,6 +45,8 @@ type = "kafka"
 inputs = {{.Inputs}}
 bootstrap_servers = {{.BootstrapServers}}
 topic = {{.Topic}}
+{{kv .Compression -}}
+{{kv .Tuning -}}
 {{end}}
 `
 }
@@ -52,13 +57,20 @@ func Conf(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Optio
 			Debug(strings.ToLower(vectorhelpers.Replacer.Replace(o.Name)), vectorhelpers.MakeInputs(inputs...)),
 		}
 	}
+	outputs := []Element{}
+	if genhelper.HasDataModel(o) {
+		outputs = append(outputs, output.DataModel(o, inputs))
+		inputs = []string{output.DataModelID(o)}
+	}
 	return MergeElements(
+		outputs,
 		[]Element{
 			Output(o, inputs, secret, op),
 			Encoding(o, op),
 		},
 		TLSConf(o, secret),
 		SASLConf(o, secret),
+		output.NewBuffer(strings.ToLower(helpers.Replacer.Replace(o.Name)), o),
 	)
 }
 
@@ -72,7 +84,23 @@ func Output(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Opt
 		Inputs:           vectorhelpers.MakeInputs(inputs...),
 		Topic:            fmt.Sprintf("%q", Topics(o)),
 		BootstrapServers: fmt.Sprintf("%q", Brokers(o)),
+		Compression:      output.Compression(o, ""),
+		Tuning:           Tuning(o),
+	}
+}
+
+// Tuning returns the batch options of the kafka sink. The kafka producer manages
+// retries and concurrency itself, only the message timeout is configurable.
+func Tuning(o logging.OutputSpec) Element {
+	t := output.NewTuning(o)
+	t.RequestTimeout = Nil
+	t.RetryInitialBackoff = Nil
+	t.RetryMaxDuration = Nil
+	t.Concurrency = Nil
+	if o.Tuning != nil && o.Tuning.RequestTimeout > 0 {
+		t.RequestTimeout = KV("message_timeout_ms", fmt.Sprintf("%d", int64(o.Tuning.RequestTimeout)*1000))
 	}
+	return t
 }
 
 //Brokers returns the list of broker endpoints of a kafka cluster.
@@ -334,6 +334,144 @@ topic = "topic"
 [sinks.kafka_receiver.encoding]
 codec = "json"
 timestamp_format = "rfc3339"
+`,
+		}),
+		Entry("with the OpenTelemetry data model", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Outputs: []logging.OutputSpec{
+					{
+						Type:      logging.OutputTypeKafka,
+						Name:      "kafka-receiver",
+						URL:       "tcp://broker1-kafka.svc.messaging.cluster.local:9092/topic",
+						DataModel: logging.DataModelOTel,
+					},
+				},
+			},
+			Secrets: security.NoSecrets,
+			ExpectedConf: `
+# Translate records to the otel data model
+[transforms.kafka_receiver_data_model]
+type = "remap"
+inputs = ["pipeline_1","pipeline_2"]
+source = '''
+  if exists(."@timestamp") {
+    .timestamp = del(."@timestamp")
+  }
+  if exists(.message) {
+    .body = del(.message)
+  }
+  if exists(.level) {
+    .severity_text = del(.level)
+  }
+  if exists(.log_type) {
+    .attributes.log_type = del(.log_type)
+  }
+  if exists(.hostname) {
+    .resource.host.name = del(.hostname)
+  }
+  if exists(.openshift.cluster_id) {
+    .resource.k8s.cluster.uid = del(.openshift.cluster_id)
+  }
+  if exists(.openshift.cluster_name) {
+    .resource.k8s.cluster.name = del(.openshift.cluster_name)
+  }
+  if exists(.kubernetes.host) {
+    .resource.k8s.node.name = del(.kubernetes.host)
+  }
+  if exists(.kubernetes.node_labels) {
+    .resource.k8s.node.label = del(.kubernetes.node_labels)
+  }
+  if exists(.kubernetes.namespace_name) {
+    .resource.k8s.namespace.name = del(.kubernetes.namespace_name)
+  }
+  if exists(.kubernetes.namespace_id) {
+    .resource.k8s.namespace.uid = del(.kubernetes.namespace_id)
+  }
+  if exists(.kubernetes.namespace_labels) {
+    .resource.k8s.namespace.label = del(.kubernetes.namespace_labels)
+  }
+  if exists(.kubernetes.pod_name) {
+    .resource.k8s.pod.name = del(.kubernetes.pod_name)
+  }
+  if exists(.kubernetes.pod_id) {
+    .resource.k8s.pod.uid = del(.kubernetes.pod_id)
+  }
+  if exists(.kubernetes.labels) {
+    .resource.k8s.pod.label = del(.kubernetes.labels)
+  }
+  if exists(.kubernetes.annotations) {
+    .resource.k8s.pod.annotation = del(.kubernetes.annotations)
+  }
+  if exists(.kubernetes.container_name) {
+    .resource.k8s.container.name = del(.kubernetes.container_name)
+  }
+  if exists(.kubernetes.container_id) {
+    .resource.container.id = del(.kubernetes.container_id)
+  }
+  if exists(.kubernetes.container_image) {
+    .resource.container.image.name = del(.kubernetes.container_image)
+  }
+  if exists(.kubernetes) {
+    .attributes.kubernetes = del(.kubernetes)
+  }
+  if exists(.openshift) {
+    .attributes.openshift = del(.openshift)
+  }
+'''
+
+# Kafka config
+[sinks.kafka_receiver]
+type = "kafka"
+inputs = ["kafka_receiver_data_model"]
+bootstrap_servers = "broker1-kafka.svc.messaging.cluster.local:9092"
+topic = "topic"
+
+[sinks.kafka_receiver.encoding]
+codec = "json"
+timestamp_format = "rfc3339"
+`,
+		}),
+		Entry("with tuning and disk buffer", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Outputs: []logging.OutputSpec{
+					{
+						Type: logging.OutputTypeKafka,
+						Name: "kafka-receiver",
+						URL:  "tcp://broker1-kafka.svc.messaging.cluster.local:9092/topic",
+						Tuning: &logging.OutputTuningSpec{
+							MaxBatchEvents: 500,
+							BatchTimeout:   2,
+							RequestTimeout: 30,
+							Concurrency:    4,
+							Compression:    "zstd",
+							Buffer: &logging.OutputBufferSpec{
+								Type: logging.OutputBufferTypeDisk,
+							},
+						},
+					},
+				},
+			},
+			Secrets: security.NoSecrets,
+			ExpectedConf: `
+# Kafka config
+[sinks.kafka_receiver]
+type = "kafka"
+inputs = ["pipeline_1","pipeline_2"]
+bootstrap_servers = "broker1-kafka.svc.messaging.cluster.local:9092"
+topic = "topic"
+compression = "zstd"
+batch.max_events = 500
+batch.timeout_secs = 2
+message_timeout_ms = 30000
+
+[sinks.kafka_receiver.encoding]
+codec = "json"
+timestamp_format = "rfc3339"
+
+[sinks.kafka_receiver.buffer]
+type = "disk"
+max_size = 1073741824
+when_full = "block"
 `,
 		}),
 	)
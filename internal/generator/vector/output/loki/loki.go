@@ -10,6 +10,7 @@ import (
 	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
 	vectorhelpers "github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output/security"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -45,6 +46,8 @@ type Loki struct {
 	TenantID    Element
 	Endpoint    string
 	LokiLabel   []string
+	Compression Element
+	Tuning      Element
 }
 
 func (l Loki) Name() string {
@@ -60,6 +63,8 @@ endpoint = "{{.Endpoint}}"
 out_of_order_action = "accept"
 healthcheck.enabled = false
 {{kv .TenantID -}}
+{{kv .Compression -}}
+{{kv .Tuning -}}
 {{end}}`
 }
 
@@ -118,6 +123,7 @@ func Conf(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Optio
 		TLSConf(o, secret),
 		BasicAuth(o, secret),
 		BearerTokenAuth(o, secret),
+		output.NewBuffer(strings.ToLower(vectorhelpers.Replacer.Replace(o.Name)), o),
 	)
 }
 
@@ -127,6 +133,8 @@ func Output(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op Opt
 		Inputs:      vectorhelpers.MakeInputs(inputs...),
 		Endpoint:    o.URL,
 		TenantID:    Tenant(o.Loki),
+		Compression: output.Compression(o, ""),
+		Tuning:      output.NewTuning(o),
 	}
 }
 
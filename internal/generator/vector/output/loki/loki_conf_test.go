@@ -139,6 +139,47 @@ kubernetes_labels_app = "{{kubernetes.labels.app}}"
 strategy = "basic"
 user = "username"
 password = "password"
+`,
+		}),
+		Entry("with disk buffer", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Outputs: []logging.OutputSpec{
+					{
+						Type: logging.OutputTypeLoki,
+						Name: "loki-receiver",
+						URL:  "https://logs-us-west1.grafana.net",
+						Tuning: &logging.OutputTuningSpec{
+							Buffer: &logging.OutputBufferSpec{
+								Type:     logging.OutputBufferTypeDisk,
+								MaxSize:  536870912,
+								WhenFull: logging.BufferWhenFullDropNewest,
+							},
+						},
+					},
+				},
+			},
+			ExpectedConf: `
+[sinks.loki_receiver]
+type = "loki"
+inputs = ["application"]
+endpoint = "https://logs-us-west1.grafana.net"
+out_of_order_action = "accept"
+healthcheck.enabled = false
+
+[sinks.loki_receiver.encoding]
+codec = "json"
+
+[sinks.loki_receiver.labels]
+kubernetes_container_name = "{{kubernetes.container_name}}"
+kubernetes_host = "${VECTOR_SELF_NODE_NAME}"
+kubernetes_namespace_name = "{{kubernetes.namespace_name}}"
+kubernetes_pod_name = "{{kubernetes.pod_name}}"
+log_type = "{{log_type}}"
+
+[sinks.loki_receiver.buffer]
+type = "disk"
+max_size = 536870912
+when_full = "drop_newest"
 `,
 		}),
 		Entry("with tenant id", helpers.ConfGenerateTest{
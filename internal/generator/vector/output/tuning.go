@@ -0,0 +1,107 @@
+package output
+
+import (
+	"fmt"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	. "github.com/openshift/cluster-logging-operator/internal/generator"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
+)
+
+const (
+	CompressionNone = "none"
+)
+
+// Tuning holds the batch and request options of a sink, as tuned by the OutputTuningSpec.
+// Options which are not set are omitted, leaving the vector defaults in place.
+type Tuning struct {
+	MaxBatchBytes       Element
+	MaxBatchEvents      Element
+	BatchTimeout        Element
+	RequestTimeout      Element
+	RetryInitialBackoff Element
+	RetryMaxDuration    Element
+	Concurrency         Element
+	Acknowledgements    Element
+}
+
+func (t Tuning) Name() string {
+	return "vectorTuningTemplate"
+}
+
+func (t Tuning) Template() string {
+	return `{{define "` + t.Name() + `" -}}
+{{optional .MaxBatchBytes -}}
+{{optional .MaxBatchEvents -}}
+{{optional .BatchTimeout -}}
+{{optional .RequestTimeout -}}
+{{optional .RetryInitialBackoff -}}
+{{optional .RetryMaxDuration -}}
+{{optional .Concurrency -}}
+{{optional .Acknowledgements -}}
+{{end}}`
+}
+
+// NewTuning returns the batch and request options of an http based sink
+func NewTuning(o logging.OutputSpec) Tuning {
+	t := Tuning{
+		MaxBatchBytes:       Nil,
+		MaxBatchEvents:      Nil,
+		BatchTimeout:        Nil,
+		RequestTimeout:      Nil,
+		RetryInitialBackoff: Nil,
+		RetryMaxDuration:    Nil,
+		Concurrency:         Nil,
+		Acknowledgements:    Nil,
+	}
+	spec := o.Tuning
+	if spec == nil {
+		return t
+	}
+	if spec.MaxBatchBytes > 0 {
+		t.MaxBatchBytes = KV("batch.max_bytes", fmt.Sprintf("%d", spec.MaxBatchBytes))
+	}
+	if spec.MaxBatchEvents > 0 {
+		t.MaxBatchEvents = KV("batch.max_events", fmt.Sprintf("%d", spec.MaxBatchEvents))
+	}
+	if spec.BatchTimeout > 0 {
+		t.BatchTimeout = KV("batch.timeout_secs", fmt.Sprintf("%d", spec.BatchTimeout))
+	}
+	if spec.RequestTimeout > 0 {
+		t.RequestTimeout = KV("request.timeout_secs", fmt.Sprintf("%d", spec.RequestTimeout))
+	}
+	if spec.MinRetryDuration > 0 {
+		t.RetryInitialBackoff = KV("request.retry_initial_backoff_secs", fmt.Sprintf("%d", spec.MinRetryDuration))
+	}
+	if spec.MaxRetryDuration > 0 {
+		t.RetryMaxDuration = KV("request.retry_max_duration_secs", fmt.Sprintf("%d", spec.MaxRetryDuration))
+	}
+	if spec.Concurrency > 0 {
+		t.Concurrency = KV("request.concurrency", fmt.Sprintf("%d", spec.Concurrency))
+	}
+	if spec.DeliveryMode == logging.OutputDeliveryModeAtLeastOnce {
+		t.Acknowledgements = KV("acknowledgements.enabled", "true")
+	}
+	return t
+}
+
+// SupportsAcknowledgements returns true if the sink of the output type acknowledges delivered records
+func SupportsAcknowledgements(outputType string) bool {
+	switch outputType {
+	case logging.OutputTypeElasticsearch, logging.OutputTypeKafka, logging.OutputTypeLoki,
+		logging.OutputTypeCloudwatch, logging.OutputTypeGoogleCloudLogging:
+		return true
+	}
+	return false
+}
+
+// Compression returns the compression of a sink, or the given default if not tuned
+func Compression(o logging.OutputSpec, defaultCompression string) Element {
+	if o.Tuning != nil && o.Tuning.Compression != "" {
+		return KV("compression", fmt.Sprintf("%q", o.Tuning.Compression))
+	}
+	if defaultCompression != "" {
+		return KV("compression", fmt.Sprintf("%q", defaultCompression))
+	}
+	return Nil
+}
@@ -27,7 +27,6 @@ func OutputFromPipelines(spec *logging.ClusterLogForwarderSpec, op generator.Opt
 func Outputs(clspec *logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec *logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
 	outputs := []generator.Element{}
 	ofp := OutputFromPipelines(clfspec, op)
-
 	for _, o := range clfspec.Outputs {
 		var secret *corev1.Secret
 		if s, ok := secrets[o.Name]; ok {
@@ -41,26 +40,36 @@ func Outputs(clspec *logging.CollectionSpec, secrets map[string]*corev1.Secret,
 				log.V(9).Info("No Secret found in " + constants.LogCollectorToken)
 			}
 		}
-		inputs := ofp[o.Name].List()
-		switch o.Type {
-		case logging.OutputTypeKafka:
-			outputs = generator.MergeElements(outputs, kafka.Conf(o, inputs, secret, op))
-		case logging.OutputTypeLoki:
-			outputs = generator.MergeElements(outputs, loki.Conf(o, inputs, secret, op))
-		case logging.OutputTypeElasticsearch:
-			outputs = generator.MergeElements(outputs, elasticsearch.Conf(o, inputs, secret, op))
-		case logging.OutputTypeCloudwatch:
-			outputs = generator.MergeElements(outputs, cloudwatch.Conf(o, inputs, secret, op))
-		case logging.OutputTypeGoogleCloudLogging:
-			outputs = generator.MergeElements(outputs, gcl.Conf(o, inputs, secret, op))
-		}
+		outputs = generator.MergeElements(outputs, OutputConf(o, ofp[o.Name].List(), secret, op))
+	}
+	metrics := []string{InternalMetricsSourceName}
+	for _, m := range clfspec.LogMetrics {
+		metrics = append(metrics, LogToMetricID(m))
 	}
+	outputs = append(outputs, LogMetrics(clfspec)...)
 	outputs = append(outputs,
-		AddNodeNameToMetric(AddNodenameToMetricTransformName, []string{InternalMetricsSourceName}),
+		AddNodeNameToMetric(AddNodenameToMetricTransformName, metrics),
 		PrometheusOutput(PrometheusOutputSinkName, []string{AddNodenameToMetricTransformName}))
 	return outputs
 }
 
+// OutputConf returns the configuration of the sink of an output
+func OutputConf(o logging.OutputSpec, inputs []string, secret *corev1.Secret, op generator.Options) []generator.Element {
+	switch o.Type {
+	case logging.OutputTypeKafka:
+		return kafka.Conf(o, inputs, secret, op)
+	case logging.OutputTypeLoki:
+		return loki.Conf(o, inputs, secret, op)
+	case logging.OutputTypeElasticsearch:
+		return elasticsearch.Conf(o, inputs, secret, op)
+	case logging.OutputTypeCloudwatch:
+		return cloudwatch.Conf(o, inputs, secret, op)
+	case logging.OutputTypeGoogleCloudLogging:
+		return gcl.Conf(o, inputs, secret, op)
+	}
+	return []generator.Element{}
+}
+
 func PrometheusOutput(id string, inputs []string) generator.Element {
 	return PrometheusExporter{
 		ID:      id,
@@ -0,0 +1,155 @@
+package vector
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+	testhelpers "github.com/openshift/cluster-logging-operator/test/helpers"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Generate vector outputs", func() {
+	var f = func(clspec logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
+		return Outputs(&clspec, secrets, &clfspec, op)
+	}
+	DescribeTable("with log metrics", testhelpers.TestGenerateConfWith(f),
+		Entry("should count the records of pipelines", testhelpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				LogMetrics: []logging.LogMetricSpec{
+					{
+						Name:         "log_records_total",
+						Type:         logging.LogMetricTypeCounter,
+						PipelineRefs: []string{"app-pipeline", "infra-pipeline"},
+						Labels: map[string]string{
+							"namespace": "kubernetes.namespace_name",
+							"level":     "level",
+						},
+					},
+					{
+						Name:         "oom_kills_total",
+						Type:         logging.LogMetricTypeCounter,
+						PipelineRefs: []string{"infra-pipeline"},
+						Match:        `Out of memory: Killed process \d+`,
+					},
+				},
+			},
+			ExpectedConf: `# Select the records of log metric "log_records_total"
+[transforms.log_metric_log_records_total]
+type = "remap"
+inputs = ["app-pipeline","infra-pipeline"]
+source = '''
+  value = 1
+  . = {"value": value, "labels": {"level": to_string(.level) ?? "", "namespace": to_string(.kubernetes.namespace_name) ?? ""}}
+'''
+
+[transforms.log_to_metric_log_records_total]
+type = "log_to_metric"
+inputs = ["log_metric_log_records_total"]
+
+[[transforms.log_to_metric_log_records_total.metrics]]
+type = "counter"
+field = "value"
+name = "log_records_total"
+tags.level = "{{ labels.level }}"
+tags.namespace = "{{ labels.namespace }}"
+
+# Select the records of log metric "oom_kills_total"
+[transforms.log_metric_oom_kills_total]
+type = "remap"
+inputs = ["infra-pipeline"]
+source = '''
+  if !match(to_string(.message) ?? "", r'Out of memory: Killed process \d+') {
+    abort
+  }
+  value = 1
+  . = {"value": value, "labels": {}}
+'''
+
+[transforms.log_to_metric_oom_kills_total]
+type = "log_to_metric"
+inputs = ["log_metric_oom_kills_total"]
+
+[[transforms.log_to_metric_oom_kills_total.metrics]]
+type = "counter"
+field = "value"
+name = "oom_kills_total"
+
+[transforms.add_nodename_to_metric]
+type = "remap"
+inputs = ["internal_metrics","log_to_metric_log_records_total","log_to_metric_oom_kills_total"]
+source = '''
+.tags.hostname = get_env_var!("VECTOR_SELF_NODE_NAME")
+'''
+
+[sinks.prometheus_output]
+type = "prometheus_exporter"
+inputs = ["add_nodename_to_metric"]
+address = "0.0.0.0:24231"
+default_namespace = "collector"
+
+[sinks.prometheus_output.tls]
+enabled = true
+key_file = "/etc/collector/metrics/tls.key"
+crt_file = "/etc/collector/metrics/tls.crt"`,
+		}),
+		Entry("should observe numeric fields of the records of pipelines", testhelpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				LogMetrics: []logging.LogMetricSpec{
+					{
+						Name:         "request_duration_seconds",
+						Type:         logging.LogMetricTypeHistogram,
+						PipelineRefs: []string{"app-pipeline"},
+						Field:        "structured.duration",
+						Labels: map[string]string{
+							"app": "kubernetes.labels.app",
+						},
+					},
+				},
+			},
+			ExpectedConf: `# Select the records of log metric "request_duration_seconds"
+[transforms.log_metric_request_duration_seconds]
+type = "remap"
+inputs = ["app-pipeline"]
+source = '''
+  if .structured.duration == null {
+    abort
+  }
+  value, err = to_float(.structured.duration)
+  if err != null {
+    abort
+  }
+  . = {"value": value, "labels": {"app": to_string(.kubernetes.labels.app) ?? ""}}
+'''
+
+[transforms.log_to_metric_request_duration_seconds]
+type = "log_to_metric"
+inputs = ["log_metric_request_duration_seconds"]
+
+[[transforms.log_to_metric_request_duration_seconds.metrics]]
+type = "histogram"
+field = "value"
+name = "request_duration_seconds"
+tags.app = "{{ labels.app }}"
+
+[transforms.add_nodename_to_metric]
+type = "remap"
+inputs = ["internal_metrics","log_to_metric_request_duration_seconds"]
+source = '''
+.tags.hostname = get_env_var!("VECTOR_SELF_NODE_NAME")
+'''
+
+[sinks.prometheus_output]
+type = "prometheus_exporter"
+inputs = ["add_nodename_to_metric"]
+address = "0.0.0.0:24231"
+default_namespace = "collector"
+
+[sinks.prometheus_output.tls]
+enabled = true
+key_file = "/etc/collector/metrics/tls.key"
+crt_file = "/etc/collector/metrics/tls.crt"`,
+		}),
+	)
+})
@@ -0,0 +1,81 @@
+package vector
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
+)
+
+// ParseMessage returns the VRL parsing the message of records into the target field of the parser
+func ParseMessage(spec *logging.PipelineParserSpec) string {
+	var parse string
+	switch spec.Type {
+	case logging.ParserTypeJSON:
+		parse = `parsed, err = parse_json(.message)`
+	case logging.ParserTypeLogfmt:
+		parse = `parsed, err = parse_logfmt(.message)`
+	case logging.ParserTypeRegex:
+		parse = fmt.Sprintf(`parsed, err = parse_regex(.message, r'%s')`, RegexLiteral(spec.Pattern))
+	case logging.ParserTypeKlog:
+		parse = fmt.Sprintf(`parsed, err = parse_regex(.message, r'%s')`, RegexLiteral(genhelper.KlogPattern))
+	case logging.ParserTypeSyslog:
+		parse = `parsed, err = parse_syslog(.message)`
+	case logging.ParserTypeCSV:
+		columns := []string{}
+		for i, h := range spec.Headers {
+			columns = append(columns, fmt.Sprintf("%q: parsed[%d]", h, i))
+		}
+		parse = fmt.Sprintf(`parsed, err = parse_csv(.message)
+if err == null {
+  parsed = {%s}
+}`, strings.Join(columns, ", "))
+	}
+	target := spec.TargetField
+	if target == "" {
+		target = logging.DefaultParserTargetField
+	}
+	set := []string{fmt.Sprintf("  %s = parsed", output.FieldPath(target))}
+	if !spec.KeepOriginal {
+		set = append(set, "  del(.message)")
+	}
+	return fmt.Sprintf("%s\nif err == null {\n%s\n}", parse, strings.Join(set, "\n"))
+}
+
+// annotatedParsers are the parsers which may be declared by pod annotations
+var annotatedParsers = []logging.ParserType{logging.ParserTypeJSON, logging.ParserTypeLogfmt, logging.ParserTypeKlog}
+
+// ParseAnnotatedMessage returns the VRL parsing the message of container records into the structured field
+// using the parser declared by the pod annotation of the container
+func ParseAnnotatedMessage() string {
+	branches := []string{}
+	for _, t := range annotatedParsers {
+		parse := ParseMessage(&logging.PipelineParserSpec{Type: t, KeepOriginal: true})
+		branches = append(branches, fmt.Sprintf("if parser == %q {\n    %s\n  }", t, strings.ReplaceAll(parse, "\n", "\n    ")))
+	}
+	return fmt.Sprintf(`if exists(.kubernetes.annotations) && exists(.kubernetes.container_name) {
+  parser = get(value: .kubernetes.annotations, path: [%q + string!(.kubernetes.container_name)]) ?? null
+  %s
+}`, logging.ParserAnnotationPrefix, strings.Join(branches, " else "))
+}
+
+var namedGroup = regexp.MustCompile(`\(\?<([a-zA-Z_])`)
+
+// RegexLiteral converts a pattern to the body of a VRL regex literal,
+// named groups use the (?P<name>) syntax of the regex engine of the collector and quotes are escaped
+func RegexLiteral(pattern string) string {
+	pattern = namedGroup.ReplaceAllString(pattern, "(?P<$1")
+	literal := strings.Builder{}
+	escaped := false
+	for _, c := range pattern {
+		if c == '\'' && !escaped {
+			literal.WriteRune('\\')
+		}
+		escaped = c == '\\' && !escaped
+		literal.WriteRune(c)
+	}
+	return literal.String()
+}
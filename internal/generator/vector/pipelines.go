@@ -7,8 +7,10 @@ import (
 
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	"github.com/openshift/cluster-logging-operator/internal/generator"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
 )
 
 const (
@@ -28,7 +30,12 @@ func Pipelines(spec *logging.ClusterLogForwarderSpec, op generator.Options) []ge
 			s, _ := json.Marshal(p.Labels)
 			vrls = append(vrls, fmt.Sprintf(".openshift.labels = %s", s))
 		}
-		if p.Parse == ParseJson {
+		if fields := StaticFields(spec, op); fields != "" {
+			vrls = append(vrls, fields)
+		}
+		if p.Parser != nil {
+			vrls = append(vrls, ParseMessage(p.Parser))
+		} else if p.Parse == ParseJson {
 			parse := `
 parsed, err = parse_json(.message)
 if err == null {
@@ -37,10 +44,39 @@ if err == null {
 `
 			vrls = append(vrls, parse)
 		}
+		if p.StructuredFields != nil && len(p.StructuredFields.Types) != 0 {
+			vrls = append(vrls, CoerceStructuredFields(genhelper.StructuredField(p), p.StructuredFields))
+		}
+		if genhelper.ProtectsStructuredFields(p) {
+			vrls = append(vrls, ProtectStructuredFields(genhelper.StructuredField(p), p.StructuredFields))
+		}
+		if p.Timestamp != nil {
+			vrls = append(vrls, ExtractTimestamp(p.Timestamp))
+		}
 		inputs := []string{}
 		for _, i := range p.InputRefs {
-			if _, ok := userDefined[i]; ok {
-				inputs = append(inputs, fmt.Sprintf(UserDefinedInput, i))
+			if input, ok := userDefined[i]; ok {
+				if input.Events != nil {
+					inputs = append(inputs, EventsInputID(input))
+				}
+				if input.File != nil {
+					inputs = append(inputs, FileInputID(input))
+				}
+				if input.Receiver != nil {
+					inputs = append(inputs, ReceiverInputID(input))
+				}
+				if len(genhelper.InputPodFiles(*input, op)) > 0 {
+					inputs = append(inputs, PodFilesInputID(input))
+				}
+				if input.Infrastructure != nil {
+					inputs = append(inputs, logging.InputNameInfrastructure)
+				}
+				if input.Audit != nil {
+					inputs = append(inputs, AuditInputID(input))
+				}
+				if input.Application != nil || (input.Events == nil && input.File == nil && input.Receiver == nil && input.Infrastructure == nil && input.Audit == nil) {
+					inputs = append(inputs, fmt.Sprintf(UserDefinedInput, i))
+				}
 			} else {
 				inputs = append(inputs, i)
 			}
@@ -59,3 +95,12 @@ if err == null {
 	}
 	return el
 }
+
+// StaticFields returns the VRL stamping the cluster identity and the static fields of the forwarder onto records
+func StaticFields(spec *logging.ClusterLogForwarderSpec, op generator.Options) string {
+	vrls := []string{}
+	for _, f := range genhelper.StaticFields(spec, op) {
+		vrls = append(vrls, fmt.Sprintf("%s = %q", output.FieldPath(f.Path), f.Value))
+	}
+	return strings.Join(vrls, "\n")
+}
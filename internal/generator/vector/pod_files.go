@@ -0,0 +1,136 @@
+package vector
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/source"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	RawPodFileLogs = "raw_pod_files"
+	PodFilesLogs   = "pod_files"
+	PodFileLogs    = "pod_files_%s"
+)
+
+// PodFileInputs returns the application inputs referenced by pipelines selecting pods with annotated files
+func PodFileInputs(spec *logging.ClusterLogForwarderSpec, op generator.Options) []*logging.InputSpec {
+	inputs := []*logging.InputSpec{}
+	for _, input := range referencedInputs(spec) {
+		if len(genhelper.InputPodFiles(*input, op)) > 0 {
+			inputs = append(inputs, input)
+		}
+	}
+	return inputs
+}
+
+// podFiles returns the files of the pods selected by any input, sorted by pod
+func podFiles(spec *logging.ClusterLogForwarderSpec, op generator.Options) []genhelper.PodFiles {
+	files := []genhelper.PodFiles{}
+	seen := sets.NewString()
+	for _, input := range PodFileInputs(spec, op) {
+		for _, f := range genhelper.InputPodFiles(*input, op) {
+			if key := f.PodID + "/" + f.Container; !seen.Has(key) {
+				seen.Insert(key)
+				files = append(files, f)
+			}
+		}
+	}
+	genhelper.SortPodFiles(files)
+	return files
+}
+
+// PodFileSources returns the file source reading the annotated files of the pods selected by the inputs
+func PodFileSources(spec *logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
+	globs := sets.NewString()
+	for _, files := range podFiles(spec, op) {
+		globs.Insert(files.Globs...)
+	}
+	if globs.Len() == 0 {
+		return []generator.Element{}
+	}
+	return []generator.Element{
+		source.File{
+			ComponentID: RawPodFileLogs,
+			Desc:        "Files listed by the annotations of the pods selected by application inputs",
+			Includes:    globs.List(),
+		},
+	}
+}
+
+// NormalizePodFileLogs attaches the kubernetes metadata of the pod and container owning each file and filters the
+// records by the namespaces and labels of each input
+func NormalizePodFileLogs(spec *logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
+	files := podFiles(spec, op)
+	if len(files) == 0 {
+		return []generator.Element{}
+	}
+	el := []generator.Element{
+		Remap{
+			ComponentID: PodFilesLogs,
+			Inputs:      helpers.MakeInputs(RawPodFileLogs),
+			VRL: strings.Join(helpers.TrimSpaces([]string{
+				AddLogTypeApp,
+				FixHostname,
+				RemoveSourceType,
+				AddPodFilesMetadata(files),
+				DetectLogLevel(spec.LevelDetection),
+				FixTimestampField,
+			}), "\n"),
+		},
+	}
+	for _, input := range PodFileInputs(spec, op) {
+		vrl := SrcPassThrough
+		if cond := appInputCondition(input.Application); cond != "" {
+			vrl = fmt.Sprintf("if !(%s) {\n  abort\n}", cond)
+		}
+		el = append(el, Remap{
+			ComponentID: PodFilesInputID(input),
+			Inputs:      helpers.MakeInputs(PodFilesLogs),
+			VRL:         vrl,
+		})
+	}
+	return el
+}
+
+// AddPodFilesMetadata sets the kubernetes metadata of the pod and container writing a file, looked up by the UID
+// of the pod and the name of the volume parsed from the path of the file. Files of a volume listed by several
+// containers are attributed to the container whose name sorts first.
+func AddPodFilesMetadata(files []genhelper.PodFiles) string {
+	metadata := map[string]interface{}{}
+	for _, f := range files {
+		keys := []string{}
+		for _, glob := range f.Globs {
+			keys = append(keys, genhelper.PodVolumeKey(glob))
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, found := metadata[key]; found {
+				continue
+			}
+			metadata[key] = map[string]interface{}{
+				"namespace_name": f.Namespace,
+				"pod_name":       f.Pod,
+				"pod_id":         f.PodID,
+				"container_name": f.Container,
+				"labels":         f.Labels,
+			}
+		}
+	}
+	return fmt.Sprintf(`parts = parse_regex(string(.file) ?? "", r'^%s/(?P<pod>[^/]+)/volumes/kubernetes\.io~empty-dir/(?P<volume>[^/]+)/') ?? {}
+.kubernetes = get(%s, [(string(parts.pod) ?? "") + "/" + (string(parts.volume) ?? "")]) ?? {}`,
+		regexp.QuoteMeta(genhelper.PodVolumesDir), vrlObject(metadata))
+}
+
+// PodFilesInputID returns the ID of the component emitting the records of the annotated files of an input
+func PodFilesInputID(input *logging.InputSpec) string {
+	return fmt.Sprintf(PodFileLogs, input.Name)
+}
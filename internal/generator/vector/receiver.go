@@ -0,0 +1,194 @@
+package vector
+
+import (
+	"fmt"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/constants"
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	. "github.com/openshift/cluster-logging-operator/internal/generator/vector/elements"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output/security"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/source"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	RawReceiverLogs   = "raw_receiver_%s"
+	ReceiverLogs      = "receiver_%s"
+	ReceiverAuditLogs = "receiver_%s_events"
+	// ReceiverFilteredLogs are the records of a receiver input selected by its audit filter
+	ReceiverFilteredLogs = "receiver_%s_filtered"
+
+	FixReceiverTimestamp = `
+if !exists(."@timestamp") {
+  ."@timestamp" = .timestamp
+}
+del(.timestamp)
+`
+	// SplitAuditEventList emits each event of an audit webhook batch as a record
+	SplitAuditEventList = `
+if .kind != "EventList" {
+  abort
+}
+. = array(.items) ?? []
+`
+	// FixSyslogHostname keeps the hostname of the message, falling back to the address of the client
+	FixSyslogHostname = `
+if !exists(.hostname) {
+  .hostname = .host
+}
+del(.host)
+`
+	SyslogSeverityToLevel = `
+severity = to_string(del(.severity)) ?? ""
+.level = "default"
+if severity == "emerg" {
+  .level = "emergency"
+} else if severity == "crit" {
+  .level = "critical"
+} else if severity == "err" {
+  .level = "error"
+} else if severity == "warning" {
+  .level = "warn"
+} else if includes(["alert", "notice", "info", "debug"], severity) {
+  .level = severity
+}
+`
+	SyslogFacilityFields = `
+.systemd.u = {}
+if exists(.facility) { .systemd.u.SYSLOG_FACILITY = del(.facility) }
+if exists(.appname) { .systemd.u.SYSLOG_IDENTIFIER = del(.appname) }
+if exists(.procid) { .systemd.u.SYSLOG_PID = del(.procid) }
+if exists(.msgid) { .systemd.u.MESSAGE_ID = del(.msgid) }
+`
+)
+
+// ReceiverSources returns a source listening for the records pushed to each receiver input
+func ReceiverSources(spec *logging.ClusterLogForwarderSpec, secrets map[string]*corev1.Secret) []generator.Element {
+	el := []generator.Element{}
+	for _, input := range genhelper.ReceiverInputs(spec) {
+		secret := secrets[genhelper.ReceiverSecretKey(input.Name)]
+		caFile := ""
+		if security.HasCABundle(secret) {
+			caFile = security.SecretPath(input.Receiver.Secret.Name, constants.TrustedCABundleKey)
+		}
+		id := generator.ComponentID(fmt.Sprintf(RawReceiverLogs, input.Name))
+		desc := fmt.Sprintf("Logs pushed to receiver input %q", input.Name)
+		certFile := genhelper.ReceiverCertPath(input.Name, corev1.TLSCertKey)
+		keyFile := genhelper.ReceiverCertPath(input.Name, corev1.TLSPrivateKeyKey)
+		if input.Receiver.Type == logging.ReceiverTypeSyslog {
+			r := source.SyslogReceiver{
+				ComponentID: id,
+				Desc:        desc,
+				Port:        input.Receiver.Port,
+				Mode:        "tcp",
+				TLS:         true,
+				CertFile:    certFile,
+				KeyFile:     keyFile,
+				CAFile:      caFile,
+			}
+			if genhelper.IsUDPReceiver(input.Receiver) {
+				r.Mode = "udp"
+				r.TLS = false
+			}
+			el = append(el, r)
+			continue
+		}
+		r := source.HTTPReceiver{
+			ComponentID: id,
+			Desc:        desc,
+			Port:        input.Receiver.Port,
+			Encoding:    "json",
+			CertFile:    certFile,
+			KeyFile:     keyFile,
+			CAFile:      caFile,
+		}
+		if input.Receiver.Type == logging.ReceiverTypeHTTP && input.Receiver.HTTP != nil && input.Receiver.HTTP.Format != "" {
+			r.Encoding = input.Receiver.HTTP.Format
+		}
+		el = append(el, r)
+	}
+	return el
+}
+
+// NormalizeReceiverLogs splits audit event batches and renames the fields of the pushed records as per the data model.
+// API audit events are normalized like the API audit logs of the nodes and selected by the audit filter of the receiver
+func NormalizeReceiverLogs(spec *logging.ClusterLogForwarderSpec) []generator.Element {
+	el := []generator.Element{}
+	for _, input := range genhelper.ReceiverInputs(spec) {
+		in := fmt.Sprintf(RawReceiverLogs, input.Name)
+		vrls := []string{}
+		switch input.Receiver.Type {
+		case logging.ReceiverTypeKubeAPIAudit:
+			el = append(el, Remap{
+				ComponentID: fmt.Sprintf(ReceiverAuditLogs, input.Name),
+				Inputs:      helpers.MakeInputs(in),
+				VRL:         strings.TrimSpace(SplitAuditEventList),
+			})
+			in = fmt.Sprintf(ReceiverAuditLogs, input.Name)
+			vrls = append(vrls,
+				AddK8sAuditTag,
+				K8sAuditEventTimestamp,
+				AddLogTypeAudit,
+				fmt.Sprintf(".input_name = %q", input.Name),
+				FixHostname,
+				FixTimestampField,
+			)
+		case logging.ReceiverTypeSyslog:
+			vrls = append(vrls,
+				AddLogTypeInfra,
+				fmt.Sprintf(".input_name = %q", input.Name),
+				FixSyslogHostname,
+				RemoveSourceType,
+				`del(.source_ip)`,
+				`del(.version)`,
+				SyslogSeverityToLevel,
+				SyslogFacilityFields,
+				FixTimestampField,
+			)
+		default:
+			vrls = append(vrls,
+				AddLogTypeApp,
+				fmt.Sprintf(".input_name = %q", input.Name),
+				FixHostname,
+				RemoveSourceType,
+				`del(.path)`,
+				DetectLogLevel(spec.LevelDetection),
+				FixReceiverTimestamp,
+			)
+		}
+		el = append(el, Remap{
+			ComponentID: fmt.Sprintf(ReceiverLogs, input.Name),
+			Inputs:      helpers.MakeInputs(in),
+			VRL:         strings.Join(helpers.TrimSpaces(vrls), "\n"),
+		})
+		if filter := receiverAuditFilter(input.Receiver); filter != nil {
+			el = append(el, Remap{
+				Desc:        fmt.Sprintf("Filter API audit events of input %q", input.Name),
+				ComponentID: fmt.Sprintf(ReceiverFilteredLogs, input.Name),
+				Inputs:      helpers.MakeInputs(fmt.Sprintf(ReceiverLogs, input.Name)),
+				VRL:         AuditFilter(filter),
+			})
+		}
+	}
+	return el
+}
+
+// receiverAuditFilter returns the audit filter of a kubeAPIAudit receiver, if any
+func receiverAuditFilter(receiver *logging.ReceiverSpec) *logging.AuditFilter {
+	if receiver.Type != logging.ReceiverTypeKubeAPIAudit || receiver.KubeAPIAudit == nil {
+		return nil
+	}
+	return receiver.KubeAPIAudit.Filter
+}
+
+// ReceiverInputID returns the ID of the component emitting the records of a receiver input
+func ReceiverInputID(input *logging.InputSpec) string {
+	if receiverAuditFilter(input.Receiver) != nil {
+		return fmt.Sprintf(ReceiverFilteredLogs, input.Name)
+	}
+	return fmt.Sprintf(ReceiverLogs, input.Name)
+}
@@ -0,0 +1,39 @@
+package source
+
+import (
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+)
+
+// File reads log files of the node matching the Includes globs
+type File struct {
+	generator.ComponentID
+	Desc     string
+	Includes []string
+	Excludes []string
+	// MultilineStartPattern matches the first line of a record, following lines are appended to it
+	MultilineStartPattern string
+}
+
+func (f File) Name() string {
+	return "file_template"
+}
+
+func (f File) Template() string {
+	return `{{define "` + f.Name() + `" -}}
+# {{.Desc}}
+[sources.{{.ComponentID}}]
+type = "file"
+include = [{{range $i, $p := .Includes}}{{if $i}}, {{end}}{{printf "%q" $p}}{{end}}]
+{{- if .Excludes}}
+exclude = [{{range $i, $p := .Excludes}}{{if $i}}, {{end}}{{printf "%q" $p}}{{end}}]
+{{- end}}
+host_key = "hostname"
+glob_minimum_cooldown_ms = 15000
+{{- if .MultilineStartPattern}}
+multiline.start_pattern = {{printf "%q" .MultilineStartPattern}}
+multiline.condition_pattern = {{printf "%q" .MultilineStartPattern}}
+multiline.mode = "halt_before"
+multiline.timeout_ms = 1000
+{{- end}}
+{{end}}`
+}
@@ -0,0 +1,38 @@
+package source
+
+import (
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+)
+
+// HTTPReceiver accepts records pushed over HTTPS by external producers
+type HTTPReceiver struct {
+	generator.ComponentID
+	Desc     string
+	Port     int32
+	Encoding string
+	CertFile string
+	KeyFile  string
+	// CAFile is the quoted path of the CAs the client certificates must be signed by, if any
+	CAFile string
+}
+
+func (r HTTPReceiver) Name() string {
+	return "httpReceiverTemplate"
+}
+
+func (r HTTPReceiver) Template() string {
+	return `{{define "` + r.Name() + `" -}}
+# {{.Desc}}
+[sources.{{.ComponentID}}]
+type = "http"
+address = "0.0.0.0:{{.Port}}"
+encoding = "{{.Encoding}}"
+tls.enabled = true
+tls.crt_file = {{printf "%q" .CertFile}}
+tls.key_file = {{printf "%q" .KeyFile}}
+{{- if .CAFile}}
+tls.ca_file = {{.CAFile}}
+tls.verify_certificate = true
+{{- end}}
+{{end}}`
+}
@@ -4,11 +4,29 @@ import (
 	"github.com/openshift/cluster-logging-operator/internal/generator"
 )
 
-const JournalLogTemplate = `
-{{define "inputSourceJournalTemplate" -}}
+// JournalLog reads the journal of the node, selecting records by unit when defined. Matches of the source are OR-ed
+// across fields, other fields are filtered after the source.
+type JournalLog struct {
+	generator.ComponentID
+	Desc         string
+	IncludeUnits []string
+	ExcludeUnits []string
+}
+
+func (j JournalLog) Name() string {
+	return "inputSourceJournalTemplate"
+}
+
+func (j JournalLog) Template() string {
+	return `{{define "` + j.Name() + `" -}}
 [sources.{{.ComponentID}}]
 type = "journald"
 journal_directory = "/var/log/journal"
+{{- if .IncludeUnits}}
+include_units = [{{range $i, $u := .IncludeUnits}}{{if $i}}, {{end}}{{printf "%q" $u}}{{end}}]
+{{- end}}
+{{- if .ExcludeUnits}}
+exclude_units = [{{range $i, $u := .ExcludeUnits}}{{if $i}}, {{end}}{{printf "%q" $u}}{{end}}]
+{{- end}}
 {{end}}`
-
-type JournalLog = generator.ConfLiteral
+}
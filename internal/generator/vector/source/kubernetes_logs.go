@@ -8,6 +8,14 @@ type KubernetesLogs struct {
 	generator.ComponentID
 	Desc         string
 	ExcludePaths string
+	// PodOwner adds the kind and name of the owner of the pod to kubernetes.pod_owner
+	PodOwner bool
+	// NamespaceLabels adds the labels of the namespace of the pod to kubernetes.namespace_labels
+	NamespaceLabels bool
+	// SkipNamespaceLabels removes the labels of the namespace of the pod
+	SkipNamespaceLabels bool
+	// NodeLabels adds the labels of the node of the pod to kubernetes.node_labels
+	NodeLabels bool
 }
 
 func (kl KubernetesLogs) Name() string {
@@ -27,5 +35,17 @@ pod_annotation_fields.pod_namespace = "kubernetes.namespace_name"
 pod_annotation_fields.pod_annotations = "kubernetes.annotations"
 pod_annotation_fields.pod_uid = "kubernetes.pod_id"
 pod_annotation_fields.pod_node_name = "hostname"
+{{- if .PodOwner}}
+pod_annotation_fields.pod_owner = "kubernetes.pod_owner"
+{{- end}}
+{{- if .NamespaceLabels}}
+namespace_annotation_fields.namespace_labels = "kubernetes.namespace_labels"
+{{- end}}
+{{- if .SkipNamespaceLabels}}
+namespace_annotation_fields.namespace_labels = ""
+{{- end}}
+{{- if .NodeLabels}}
+node_annotation_fields.node_labels = "kubernetes.node_labels"
+{{- end}}
 {{end}}`
 }
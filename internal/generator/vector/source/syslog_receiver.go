@@ -0,0 +1,42 @@
+package source
+
+import (
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+)
+
+// SyslogReceiver accepts RFC3164 and RFC5424 messages sent by syslog clients over TCP, with TLS, or UDP
+type SyslogReceiver struct {
+	generator.ComponentID
+	Desc string
+	Port int32
+	Mode string
+	// TLS is enabled over TCP only
+	TLS      bool
+	CertFile string
+	KeyFile  string
+	// CAFile is the quoted path of the CAs the client certificates must be signed by, if any
+	CAFile string
+}
+
+func (r SyslogReceiver) Name() string {
+	return "syslogReceiverTemplate"
+}
+
+func (r SyslogReceiver) Template() string {
+	return `{{define "` + r.Name() + `" -}}
+# {{.Desc}}
+[sources.{{.ComponentID}}]
+type = "syslog"
+address = "0.0.0.0:{{.Port}}"
+mode = "{{.Mode}}"
+{{- if .TLS}}
+tls.enabled = true
+tls.crt_file = {{printf "%q" .CertFile}}
+tls.key_file = {{printf "%q" .KeyFile}}
+{{- if .CAFile}}
+tls.ca_file = {{.CAFile}}
+tls.verify_certificate = true
+{{- end}}
+{{- end}}
+{{end}}`
+}
@@ -6,14 +6,20 @@ import (
 
 	"github.com/openshift/cluster-logging-operator/internal/constants"
 	"github.com/openshift/cluster-logging-operator/internal/generator"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	"github.com/openshift/cluster-logging-operator/internal/generator/vector/source"
 
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
 	RawHostAuditLogs = "raw_host_audit_logs"
 	HostAuditLogs    = "host_audit_logs"
+	HostAuditEvents  = "host_audit_events"
+	// HostAuditRecords and HostAuditReduced are the linux audit records before and after their correlation
+	HostAuditRecords = "host_audit_records"
+	HostAuditReduced = "host_audit_reduced"
 
 	RawK8sAuditLogs = "raw_k8s_audit_logs"
 	K8sAuditLogs    = "k8s_audit_logs"
@@ -25,32 +31,42 @@ const (
 	OvnAuditLogs    = "ovn_audit_logs"
 )
 
-func Sources(spec *logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
+func Sources(spec *logging.ClusterLogForwarderSpec, secrets map[string]*corev1.Secret, op generator.Options) []generator.Element {
 	return generator.MergeElements(
-		LogSources(spec, op),
+		LogSources(spec, secrets, op),
 		MetricsSources(InternalMetricsSourceName),
 	)
 }
 
-func LogSources(spec *logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
+func LogSources(spec *logging.ClusterLogForwarderSpec, secrets map[string]*corev1.Secret, op generator.Options) []generator.Element {
 	var el []generator.Element = make([]generator.Element, 0)
 	types := generator.GatherSources(spec, op)
-	if types.Has(logging.InputNameApplication) || types.Has(logging.InputNameInfrastructure) {
-		el = append(el,
-			source.KubernetesLogs{
-				ComponentID:  "raw_container_logs",
-				Desc:         "Logs from containers (including openshift containers)",
-				ExcludePaths: ExcludeContainerPaths(),
-			})
+	if types.HasAny(logging.InputNameApplication, logging.InputNameInfrastructure, logging.InputNameEvents) {
+		k8sLogs := source.KubernetesLogs{
+			ComponentID:  "raw_container_logs",
+			Desc:         "Logs from containers (including openshift containers)",
+			ExcludePaths: ExcludeContainerPaths(),
+		}
+		if spec.Enrichment != nil {
+			k8sLogs.PodOwner = spec.Enrichment.Workload
+			if spec.Enrichment.NamespaceLabels != nil {
+				k8sLogs.NamespaceLabels = *spec.Enrichment.NamespaceLabels
+				k8sLogs.SkipNamespaceLabels = !*spec.Enrichment.NamespaceLabels
+			}
+			k8sLogs.NodeLabels = len(spec.Enrichment.NodeLabels) != 0
+		}
+		el = append(el, k8sLogs)
 	}
 	if types.Has(logging.InputNameInfrastructure) {
-		el = append(el,
-			source.JournalLog{
-				ComponentID:  "raw_journal_logs",
-				Desc:         "Logs from linux journal",
-				TemplateName: "inputSourceJournalTemplate",
-				TemplateStr:  source.JournalLogTemplate,
-			})
+		journal := source.JournalLog{
+			ComponentID: "raw_journal_logs",
+			Desc:        "Logs from linux journal",
+		}
+		if filter := genhelper.InfrastructureJournal(spec); filter != nil {
+			journal.IncludeUnits = genhelper.JournalUnits(filter.IncludeUnits)
+			journal.ExcludeUnits = genhelper.JournalUnits(filter.ExcludeUnits)
+		}
+		el = append(el, journal)
 	}
 	if types.Has(logging.InputNameAudit) {
 		el = append(el,
@@ -79,6 +95,15 @@ func LogSources(spec *logging.ClusterLogForwarderSpec, op generator.Options) []g
 				TemplateStr:  source.OVNAuditLogTemplate,
 			})
 	}
+	if types.Has(logging.InputNameFile) {
+		el = append(el, FileSources(spec)...)
+	}
+	if types.Has(logging.InputNameReceiver) {
+		el = append(el, ReceiverSources(spec, secrets)...)
+	}
+	if types.Has(logging.InputNameApplication) {
+		el = append(el, PodFileSources(spec, op)...)
+	}
 	return el
 }
 
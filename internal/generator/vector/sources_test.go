@@ -5,14 +5,17 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	"github.com/openshift/cluster-logging-operator/internal/generator"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/utils"
 	"github.com/openshift/cluster-logging-operator/test/helpers"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("Vector Config Generation", func() {
 	var f = func(clspec logging.CollectionSpec, secrets map[string]*corev1.Secret, clfspec logging.ClusterLogForwarderSpec, op generator.Options) []generator.Element {
 		return generator.MergeElements(
-			LogSources(&clfspec, op),
+			LogSources(&clfspec, secrets, op),
 		)
 	}
 	DescribeTable("Source(s)", helpers.TestGenerateConfWith(f),
@@ -40,6 +43,40 @@ pod_annotation_fields.pod_namespace = "kubernetes.namespace_name"
 pod_annotation_fields.pod_annotations = "kubernetes.annotations"
 pod_annotation_fields.pod_uid = "kubernetes.pod_id"
 pod_annotation_fields.pod_node_name = "hostname"
+`,
+		}),
+		Entry("Application with enrichment", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs: []string{
+							logging.InputNameApplication,
+						},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+				Enrichment: &logging.EnrichmentSpec{
+					Workload:        true,
+					NamespaceLabels: utils.GetBool(true),
+					NodeLabels:      []string{"topology.kubernetes.io/zone"},
+				},
+			},
+			ExpectedConf: `
+# Logs from containers (including openshift containers)
+[sources.raw_container_logs]
+type = "kubernetes_logs"
+glob_minimum_cooldown_ms = 15000
+auto_partial_merge = true
+exclude_paths_glob_patterns = ["/var/log/pods/openshift-logging_collector-*/*/*.log", "/var/log/pods/openshift-logging_elasticsearch-*/*/*.log", "/var/log/pods/openshift-logging_kibana-*/*/*.log"]
+pod_annotation_fields.pod_labels = "kubernetes.labels"
+pod_annotation_fields.pod_namespace = "kubernetes.namespace_name"
+pod_annotation_fields.pod_annotations = "kubernetes.annotations"
+pod_annotation_fields.pod_uid = "kubernetes.pod_id"
+pod_annotation_fields.pod_node_name = "hostname"
+pod_annotation_fields.pod_owner = "kubernetes.pod_owner"
+namespace_annotation_fields.namespace_labels = "kubernetes.namespace_labels"
+node_annotation_fields.node_labels = "kubernetes.node_labels"
 `,
 		}),
 		Entry("Only Infrastructure", helpers.ConfGenerateTest{
@@ -70,6 +107,49 @@ pod_annotation_fields.pod_node_name = "hostname"
 [sources.raw_journal_logs]
 type = "journald"
 journal_directory = "/var/log/journal"
+`,
+		}),
+		Entry("Infrastructure with journal filters", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "nodes",
+						Infrastructure: &logging.Infrastructure{
+							Journal: &logging.Journal{
+								IncludeUnits: []string{"kubelet", "crio.service"},
+								ExcludeUnits: []string{"systemd-journald"},
+								MaxPriority:  "info",
+								Transports:   []logging.JournalTransport{logging.JournalTransportJournal, logging.JournalTransportStdout},
+							},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"nodes"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `
+# Logs from containers (including openshift containers)
+[sources.raw_container_logs]
+type = "kubernetes_logs"
+glob_minimum_cooldown_ms = 15000
+auto_partial_merge = true
+exclude_paths_glob_patterns = ["/var/log/pods/openshift-logging_collector-*/*/*.log", "/var/log/pods/openshift-logging_elasticsearch-*/*/*.log", "/var/log/pods/openshift-logging_kibana-*/*/*.log"]
+pod_annotation_fields.pod_labels = "kubernetes.labels"
+pod_annotation_fields.pod_namespace = "kubernetes.namespace_name"
+pod_annotation_fields.pod_annotations = "kubernetes.annotations"
+pod_annotation_fields.pod_uid = "kubernetes.pod_id"
+pod_annotation_fields.pod_node_name = "hostname"
+
+[sources.raw_journal_logs]
+type = "journald"
+journal_directory = "/var/log/journal"
+include_units = ["kubelet.service", "crio.service"]
+exclude_units = ["systemd-journald.service"]
 `,
 		}),
 		Entry("Only Audit", helpers.ConfGenerateTest{
@@ -172,6 +252,246 @@ type = "file"
 include = ["/var/log/ovn/acl-audit-log.log"]
 host_key = "hostname"
 glob_minimum_cooldown_ms = 15000
+`,
+		}),
+		Entry("File input with excludes and multiline records", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "vendor",
+						File: &logging.File{
+							Paths:                 []string{"/var/log/vendor/*.log", "/var/log/vendor/agent/*.log"},
+							Excludes:              []string{"/var/log/vendor/debug*.log"},
+							MultilineStartPattern: `^\d{4}-\d{2}-\d{2}`,
+							LogType:               logging.InputNameApplication,
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"vendor"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `
+# Logs from files of the node for input "vendor"
+[sources.raw_file_vendor]
+type = "file"
+include = ["/var/log/vendor/*.log", "/var/log/vendor/agent/*.log"]
+exclude = ["/var/log/vendor/debug*.log"]
+host_key = "hostname"
+glob_minimum_cooldown_ms = 15000
+multiline.start_pattern = "^\\d{4}-\\d{2}-\\d{2}"
+multiline.condition_pattern = "^\\d{4}-\\d{2}-\\d{2}"
+multiline.mode = "halt_before"
+multiline.timeout_ms = 1000
+`,
+		}),
+		Entry("Application input with annotated pod files", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "myapp",
+						Application: &logging.Application{
+							Namespaces:     []string{"myproject"},
+							AnnotatedFiles: true,
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"myapp"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			Options: generator.Options{
+				generator.PodFiles: []genhelper.PodFiles{
+					{
+						Namespace: "myproject",
+						Pod:       "legacy-0",
+						PodID:     "6a0f4a45-1c1e-4b4e-9d4e-0e4b5c6f7a80",
+						Container: "app",
+						Labels:    map[string]string{"app": "legacy"},
+						Globs:     []string{"/var/lib/kubelet/pods/6a0f4a45-1c1e-4b4e-9d4e-0e4b5c6f7a80/volumes/kubernetes.io~empty-dir/logs/*.log"},
+					},
+					{
+						Namespace: "other",
+						Pod:       "ignored-0",
+						PodID:     "0d7e5c1a-8f1b-4c2d-a3e4-5f6a7b8c9d01",
+						Container: "app",
+						Globs:     []string{"/var/lib/kubelet/pods/0d7e5c1a-8f1b-4c2d-a3e4-5f6a7b8c9d01/volumes/kubernetes.io~empty-dir/logs/*.log"},
+					},
+				},
+			},
+			ExpectedConf: `# Logs from containers (including openshift containers)
+[sources.raw_container_logs]
+type = "kubernetes_logs"
+glob_minimum_cooldown_ms = 15000
+auto_partial_merge = true
+exclude_paths_glob_patterns = ["/var/log/pods/openshift-logging_collector-*/*/*.log", "/var/log/pods/openshift-logging_elasticsearch-*/*/*.log", "/var/log/pods/openshift-logging_kibana-*/*/*.log"]
+pod_annotation_fields.pod_labels = "kubernetes.labels"
+pod_annotation_fields.pod_namespace = "kubernetes.namespace_name"
+pod_annotation_fields.pod_annotations = "kubernetes.annotations"
+pod_annotation_fields.pod_uid = "kubernetes.pod_id"
+pod_annotation_fields.pod_node_name = "hostname"
+
+# Files listed by the annotations of the pods selected by application inputs
+[sources.raw_pod_files]
+type = "file"
+include = ["/var/lib/kubelet/pods/6a0f4a45-1c1e-4b4e-9d4e-0e4b5c6f7a80/volumes/kubernetes.io~empty-dir/logs/*.log"]
+host_key = "hostname"
+glob_minimum_cooldown_ms = 15000`,
+		}),
+		Entry("HTTP receiver authenticating clients with certificates", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "myapp",
+						Receiver: &logging.ReceiverSpec{
+							Type:   logging.ReceiverTypeHTTP,
+							Port:   8443,
+							Secret: &logging.OutputSecretSpec{Name: "myapp-clients"},
+							HTTP:   &logging.HTTPReceiver{Format: "ndjson"},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"myapp"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			Secrets: map[string]*corev1.Secret{
+				"input/myapp": {
+					ObjectMeta: metav1.ObjectMeta{Name: "myapp-clients"},
+					Data: map[string][]byte{
+						"ca-bundle.crt": []byte("-- cert --"),
+					},
+				},
+			},
+			ExpectedConf: `
+# Logs pushed to receiver input "myapp"
+[sources.raw_receiver_myapp]
+type = "http"
+address = "0.0.0.0:8443"
+encoding = "ndjson"
+tls.enabled = true
+tls.crt_file = "/etc/collector/receiver/myapp/tls.crt"
+tls.key_file = "/etc/collector/receiver/myapp/tls.key"
+tls.ca_file = "/var/run/ocp-collector/secrets/myapp-clients/ca-bundle.crt"
+tls.verify_certificate = true
+`,
+		}),
+		Entry("Syslog receiver over TCP with client certificates", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "appliances",
+						Receiver: &logging.ReceiverSpec{
+							Type:   logging.ReceiverTypeSyslog,
+							Port:   6514,
+							Secret: &logging.OutputSecretSpec{Name: "appliances-ca"},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"appliances"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			Secrets: map[string]*corev1.Secret{
+				"input/appliances": {
+					ObjectMeta: metav1.ObjectMeta{Name: "appliances-ca"},
+					Data:       map[string][]byte{"ca-bundle.crt": []byte("-- cert --")},
+				},
+			},
+			ExpectedConf: `
+# Logs pushed to receiver input "appliances"
+[sources.raw_receiver_appliances]
+type = "syslog"
+address = "0.0.0.0:6514"
+mode = "tcp"
+tls.enabled = true
+tls.crt_file = "/etc/collector/receiver/appliances/tls.crt"
+tls.key_file = "/etc/collector/receiver/appliances/tls.key"
+tls.ca_file = "/var/run/ocp-collector/secrets/appliances-ca/ca-bundle.crt"
+tls.verify_certificate = true
+`,
+		}),
+		Entry("Syslog receiver over UDP", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "appliances",
+						Receiver: &logging.ReceiverSpec{
+							Type:   logging.ReceiverTypeSyslog,
+							Port:   5514,
+							Syslog: &logging.SyslogReceiver{Protocol: "udp"},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"appliances"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `
+# Logs pushed to receiver input "appliances"
+[sources.raw_receiver_appliances]
+type = "syslog"
+address = "0.0.0.0:5514"
+mode = "udp"
+`,
+		}),
+		Entry("Kubernetes API audit webhook receiver", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "hosted-audit",
+						Receiver: &logging.ReceiverSpec{
+							Type:   logging.ReceiverTypeKubeAPIAudit,
+							Port:   9443,
+							Secret: &logging.OutputSecretSpec{Name: "apiserver"},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"hosted-audit"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			Secrets: map[string]*corev1.Secret{
+				"input/hosted-audit": {
+					ObjectMeta: metav1.ObjectMeta{Name: "apiserver"},
+					Data:       map[string][]byte{"ca-bundle.crt": []byte("-- cert --")},
+				},
+			},
+			ExpectedConf: `
+# Logs pushed to receiver input "hosted-audit"
+[sources.raw_receiver_hosted-audit]
+type = "http"
+address = "0.0.0.0:9443"
+encoding = "json"
+tls.enabled = true
+tls.crt_file = "/etc/collector/receiver/hosted-audit/tls.crt"
+tls.key_file = "/etc/collector/receiver/hosted-audit/tls.key"
+tls.ca_file = "/var/run/ocp-collector/secrets/apiserver/ca-bundle.crt"
+tls.verify_certificate = true
 `,
 		}),
 	)
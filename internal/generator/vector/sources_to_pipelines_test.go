@@ -7,6 +7,7 @@ import (
 
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
 	"github.com/openshift/cluster-logging-operator/internal/generator"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -237,6 +238,427 @@ inputs = ["route_application_logs.myapplogs"]
 source = '''
   .
 '''
+`,
+		}),
+		Entry("Collect Kubernetes events filtered by namespace with deduplication", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "myevents",
+						Events: &logging.Events{
+							Namespaces:  []string{"test-ns1", "test-ns2"},
+							Deduplicate: true,
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"myevents", logging.InputNameInfrastructure},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.events = '(.kubernetes.namespace_name == "openshift-logging") && (.kubernetes.labels."app.kubernetes.io/name" == "eventrouter")'
+route.infra = '((starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube")) && (!((.kubernetes.namespace_name == "openshift-logging") && (.kubernetes.labels."app.kubernetes.io/name" == "eventrouter")))'
+
+# Set log_type to "infrastructure"
+[transforms.infrastructure]
+type = "remap"
+inputs = ["route_container_logs.infra","journal_logs"]
+source = '''
+  .log_type = "infrastructure"
+'''
+
+# Parse Kubernetes events and set log_type to "infrastructure"
+[transforms.events]
+type = "remap"
+inputs = ["route_container_logs.events"]
+source = '''
+  .log_type = "infrastructure"
+  parsed, err = parse_json(.message)
+  if err == null && is_object(parsed.event) {
+    .kubernetes.event = parsed.event
+    .kubernetes.event.verb = parsed.verb
+    .message = .kubernetes.event.message
+    timestamp, err = to_timestamp(.kubernetes.event.lastTimestamp)
+    if err == null {
+      ."@timestamp" = timestamp
+    }
+    if .kubernetes.event.type == "Warning" {
+      .level = "warn"
+    } else {
+      .level = "info"
+    }
+  }
+'''
+
+[transforms.route_events]
+type = "route"
+inputs = ["events"]
+route.myevents = '(.kubernetes.event.involvedObject.namespace == "test-ns1") || (.kubernetes.event.involvedObject.namespace == "test-ns2")'
+
+# Drop repeated updates of events for input "myevents"
+[transforms.myevents_dedupe]
+type = "dedupe"
+inputs = ["route_events.myevents"]
+fields.match = ["kubernetes.event.metadata.uid", "kubernetes.event.type", "kubernetes.event.reason", "kubernetes.event.message"]
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["myevents_dedupe","infrastructure"]
+source = '''
+  .
+'''
+`,
+		}),
+		Entry("Collect Kubernetes events from all namespaces with the event router in another namespace", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name:   "allevents",
+						Events: &logging.Events{},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"allevents"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			Options: generator.Options{
+				generator.EventRouterNamespace: "my-logging",
+			},
+			ExpectedConf: `
+[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.events = '(.kubernetes.namespace_name == "my-logging") && (.kubernetes.labels."app.kubernetes.io/name" == "eventrouter")'
+
+# Parse Kubernetes events and set log_type to "infrastructure"
+[transforms.events]
+type = "remap"
+inputs = ["route_container_logs.events"]
+source = '''
+  .log_type = "infrastructure"
+  parsed, err = parse_json(.message)
+  if err == null && is_object(parsed.event) {
+    .kubernetes.event = parsed.event
+    .kubernetes.event.verb = parsed.verb
+    .message = .kubernetes.event.message
+    timestamp, err = to_timestamp(.kubernetes.event.lastTimestamp)
+    if err == null {
+      ."@timestamp" = timestamp
+    }
+    if .kubernetes.event.type == "Warning" {
+      .level = "warn"
+    } else {
+      .level = "info"
+    }
+  }
+'''
+
+[transforms.route_events]
+type = "route"
+inputs = ["events"]
+route.allevents = 'true'
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["route_events.allevents"]
+source = '''
+  .
+'''
+`,
+		}),
+		Entry("Send logs of a filtered infrastructure input to a pipeline", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "nodes",
+						Infrastructure: &logging.Infrastructure{
+							Journal: &logging.Journal{
+								IncludeUnits: []string{"kubelet"},
+								MaxPriority:  "warning",
+								Transports:   []logging.JournalTransport{logging.JournalTransportJournal, logging.JournalTransportStdout},
+							},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"nodes"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.infra = '(starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube")'
+
+# Set log_type to "infrastructure"
+[transforms.infrastructure]
+type = "remap"
+inputs = ["route_container_logs.infra","journal_logs"]
+source = '''
+  .log_type = "infrastructure"
+'''
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["infrastructure"]
+source = '''
+  .
+'''`,
+		}),
+		Entry("Send logs of a file input to a pipeline", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "vendor",
+						File: &logging.File{Paths: []string{"/var/log/vendor/*.log"}},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"vendor"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.pipeline]
+type = "remap"
+inputs = ["file_vendor"]
+source = '''
+  .
+'''
+`,
+		}),
+		Entry("Send logs of annotated pod files to a pipeline", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "myapp",
+						Application: &logging.Application{
+							Namespaces:     []string{"myproject"},
+							AnnotatedFiles: true,
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"myapp"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			Options: generator.Options{
+				generator.PodFiles: []genhelper.PodFiles{
+					{
+						Namespace: "myproject",
+						Pod:       "legacy-0",
+						PodID:     "6a0f4a45-1c1e-4b4e-9d4e-0e4b5c6f7a80",
+						Container: "app",
+						Labels:    map[string]string{"app": "legacy"},
+						Globs:     []string{"/var/lib/kubelet/pods/6a0f4a45-1c1e-4b4e-9d4e-0e4b5c6f7a80/volumes/kubernetes.io~empty-dir/logs/*.log"},
+					},
+					{
+						Namespace: "other",
+						Pod:       "ignored-0",
+						PodID:     "0d7e5c1a-8f1b-4c2d-a3e4-5f6a7b8c9d01",
+						Container: "app",
+						Globs:     []string{"/var/lib/kubelet/pods/0d7e5c1a-8f1b-4c2d-a3e4-5f6a7b8c9d01/volumes/kubernetes.io~empty-dir/logs/*.log"},
+					},
+				},
+			},
+			ExpectedConf: `[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.app = '!((starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube"))'
+
+# Set log_type to "application"
+[transforms.application]
+type = "remap"
+inputs = ["route_container_logs.app"]
+source = '''
+  .log_type = "application"
+'''
+
+[transforms.route_application_logs]
+type = "route"
+inputs = ["application"]
+route.myapp = '.kubernetes.namespace_name == "myproject"'
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["pod_files_myapp","route_application_logs.myapp"]
+source = '''
+  .
+'''`,
+		}),
+		Entry("Filter API audit events with the rules of an audit input", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "siem",
+						Audit: &logging.Audit{
+							Filter: &logging.AuditFilter{
+								Rules: []logging.AuditRule{
+									{
+										Level: logging.AuditLevelNone,
+										Users: []string{"system:apiserver"},
+									},
+									{
+										Level:      logging.AuditLevelRequestResponse,
+										Verbs:      []string{"create", "update", "patch", "delete"},
+										Resources:  []logging.AuditGroupResources{{Resources: []string{"secrets"}}, {Group: "rbac.authorization.k8s.io"}},
+										OmitStages: []logging.AuditStage{"ResponseStarted"},
+									},
+									{
+										Level:     logging.AuditLevelMetadata,
+										Verbs:     []string{"delete"},
+										Resources: []logging.AuditGroupResources{{Resources: []string{"namespaces"}}},
+									},
+								},
+								OmitStages: []logging.AuditStage{"RequestReceived"},
+							},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"siem"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `# Set log_type to "audit"
+[transforms.audit]
+type = "remap"
+inputs = ["host_audit_logs","k8s_audit_logs","openshift_audit_logs","ovn_audit_logs"]
+source = '''
+  .log_type = "audit"
+  .hostname = get_env_var("VECTOR_SELF_NODE_NAME") ?? ""
+  ."@timestamp" = del(.timestamp)
+'''
+
+# Filter API audit events of input "siem"
+[transforms.audit_siem]
+type = "remap"
+inputs = ["audit"]
+source = '''
+  if .tag == ".k8s-audit.log" || .tag == ".openshift-audit.log" {
+    stage = string(.stage) ?? ""
+    verb = string(.verb) ?? ""
+    user = string(.user.username) ?? ""
+    namespace = string(.objectRef.namespace) ?? ""
+    group = string(.objectRef.apiGroup) ?? ""
+    resource = string(.objectRef.resource) ?? ""
+    subresource = string(.objectRef.subresource) ?? ""
+    if resource != "" && subresource != "" {
+      resource = resource + "/" + subresource
+    }
+    level = "None"
+    omit = []
+    if includes(["system:apiserver"], user) {
+      level = "None"
+      omit = []
+    } else if (includes(["create","update","patch","delete"], verb)) && (((group == "") && (includes(["secrets"], resource))) || ((group == "rbac.authorization.k8s.io") && (resource != ""))) {
+      level = "RequestResponse"
+      omit = ["ResponseStarted"]
+    } else if (includes(["delete"], verb)) && ((group == "") && (includes(["namespaces"], resource))) {
+      level = "Metadata"
+      omit = []
+    }
+    omit = append(omit, ["RequestReceived"])
+    if includes(omit, stage) || level == "None" {
+      abort
+    }
+    if level == "Metadata" {
+      del(.requestObject)
+      del(.responseObject)
+    } else if level == "Request" {
+      del(.responseObject)
+    }
+  }
+'''
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["audit_siem"]
+source = '''
+  .
+'''`,
+		}),
+		Entry("Send correlated linux audit events to a pipeline", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				HostAudit: &logging.HostAuditSpec{Correlate: true},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameAudit},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `
+# Set log_type to "audit"
+[transforms.audit]
+type = "remap"
+inputs = ["host_audit_events","k8s_audit_logs","openshift_audit_logs","ovn_audit_logs"]
+source = '''
+  .log_type = "audit"
+  .hostname = get_env_var("VECTOR_SELF_NODE_NAME") ?? ""
+  ."@timestamp" = del(.timestamp)
+'''
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["audit"]
+source = '''
+  .
+'''
+`,
+		}),
+		Entry("Send logs of a receiver input to a pipeline", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Inputs: []logging.InputSpec{
+					{
+						Name: "myapp",
+						Receiver: &logging.ReceiverSpec{
+							Type:   logging.ReceiverTypeHTTP,
+							Port:   8443,
+							Secret: &logging.OutputSecretSpec{Name: "myapp-clients"},
+						},
+					},
+				},
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{"myapp"},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.pipeline]
+type = "remap"
+inputs = ["receiver_myapp"]
+source = '''
+  .
+'''
 `,
 		}),
 		Entry("Add Openshift Label(s)", helpers.ConfGenerateTest{
@@ -246,8 +668,142 @@ source = '''
 						InputRefs:  []string{logging.InputNameApplication, logging.InputNameInfrastructure},
 						OutputRefs: []string{logging.OutputNameDefault},
 						Name:       "pipeline",
-						Labels: map[string]string{
-							"label1": "value1",
+						Labels: map[string]string{
+							"label1": "value1",
+						},
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.app = '!((starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube"))'
+route.infra = '(starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube")'
+
+# Set log_type to "application"
+[transforms.application]
+type = "remap"
+inputs = ["route_container_logs.app"]
+source = '''
+  .log_type = "application"
+'''
+
+# Set log_type to "infrastructure"
+[transforms.infrastructure]
+type = "remap"
+inputs = ["route_container_logs.infra","journal_logs"]
+source = '''
+  .log_type = "infrastructure"
+'''
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["application","infrastructure"]
+source = '''
+  .openshift.labels = {"label1":"value1"}
+'''
+`,
+		}),
+		Entry("Add cluster identity and static fields", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+					},
+				},
+				ClusterIdentity: true,
+				StaticFields: map[string]string{
+					"openshift.region": "eu-west-1",
+					"team":             "payments",
+				},
+			},
+			Options: generator.Options{
+				generator.ClusterID:   "0b5c2ed7-7d31-4c4c-a1a6-7e8e0f8b5a7c",
+				generator.ClusterName: "cluster-1",
+			},
+			ExpectedConf: `
+[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.app = '!((starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube"))'
+
+# Set log_type to "application"
+[transforms.application]
+type = "remap"
+inputs = ["route_container_logs.app"]
+source = '''
+  .log_type = "application"
+'''
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["application"]
+source = '''
+  .openshift.cluster_id = "0b5c2ed7-7d31-4c4c-a1a6-7e8e0f8b5a7c"
+  .openshift.cluster_name = "cluster-1"
+  .openshift.region = "eu-west-1"
+  .team = "payments"
+'''
+`,
+		}),
+		Entry("Parse log message as Jaon", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication, logging.InputNameInfrastructure},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+						Parse:      "json",
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.app = '!((starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube"))'
+route.infra = '(starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube")'
+
+# Set log_type to "application"
+[transforms.application]
+type = "remap"
+inputs = ["route_container_logs.app"]
+source = '''
+  .log_type = "application"
+'''
+
+# Set log_type to "infrastructure"
+[transforms.infrastructure]
+type = "remap"
+inputs = ["route_container_logs.infra","journal_logs"]
+source = '''
+  .log_type = "infrastructure"
+'''
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["application","infrastructure"]
+source = '''
+  parsed, err = parse_json(.message)
+  if err == null {
+    .structured = parsed
+  }
+'''
+`,
+		}),
+		Entry("Parse log message as CSV with headers", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication, logging.InputNameInfrastructure},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+						Parser: &logging.PipelineParserSpec{
+							Type:    logging.ParserTypeCSV,
+							Headers: []string{"client", "method", "status"},
 						},
 					},
 				},
@@ -279,11 +835,220 @@ source = '''
 type = "remap"
 inputs = ["application","infrastructure"]
 source = '''
-  .openshift.labels = {"label1":"value1"}
+  parsed, err = parse_csv(.message)
+  if err == null {
+    parsed = {"client": parsed[0], "method": parsed[1], "status": parsed[2]}
+  }
+  if err == null {
+    .structured = parsed
+    del(.message)
+  }
 '''
 `,
 		}),
-		Entry("Parse log message as Jaon", helpers.ConfGenerateTest{
+		Entry("Parse log message with regex into target field keeping the original", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication, logging.InputNameInfrastructure},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+						Parser: &logging.PipelineParserSpec{
+							Type:         logging.ParserTypeRegex,
+							Pattern:      `^(?<level>[A-Z]+) '(?<msg>.*)'$`,
+							TargetField:  "access",
+							KeepOriginal: true,
+						},
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.app = '!((starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube"))'
+route.infra = '(starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube")'
+
+# Set log_type to "application"
+[transforms.application]
+type = "remap"
+inputs = ["route_container_logs.app"]
+source = '''
+  .log_type = "application"
+'''
+
+# Set log_type to "infrastructure"
+[transforms.infrastructure]
+type = "remap"
+inputs = ["route_container_logs.infra","journal_logs"]
+source = '''
+  .log_type = "infrastructure"
+'''
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["application","infrastructure"]
+source = '''
+  parsed, err = parse_regex(.message, r'^(?P<level>[A-Z]+) \'(?P<msg>.*)\'$')
+  if err == null {
+    .access = parsed
+  }
+'''
+`,
+		}),
+		Entry("Coerce structured fields and suffix undeclared fields with their type", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication, logging.InputNameInfrastructure},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+						Parse:      "json",
+						StructuredFields: &logging.StructuredFieldsSpec{
+							Types: map[string]logging.FieldType{
+								"status":         logging.FieldTypeInteger,
+								"request.bytes":  logging.FieldTypeFloat,
+								"request.secure": logging.FieldTypeBoolean,
+							},
+							Conflicts: logging.StructuredFieldConflictsSuffix,
+						},
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.app = '!((starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube"))'
+route.infra = '(starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube")'
+
+# Set log_type to "application"
+[transforms.application]
+type = "remap"
+inputs = ["route_container_logs.app"]
+source = '''
+  .log_type = "application"
+'''
+
+# Set log_type to "infrastructure"
+[transforms.infrastructure]
+type = "remap"
+inputs = ["route_container_logs.infra","journal_logs"]
+source = '''
+  .log_type = "infrastructure"
+'''
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["application","infrastructure"]
+source = '''
+  parsed, err = parse_json(.message)
+  if err == null {
+    .structured = parsed
+  }
+  
+  if exists(.structured.request.bytes) {
+    .structured.request.bytes, err = to_float(.structured.request.bytes)
+    if err != null {
+      del(.structured.request.bytes)
+    }
+  }
+  if exists(.structured.request.secure) {
+    .structured.request.secure, err = to_bool(.structured.request.secure)
+    if err != null {
+      del(.structured.request.secure)
+    }
+  }
+  if exists(.structured.status) {
+    .structured.status, err = to_int(.structured.status)
+    if err != null {
+      del(.structured.status)
+    }
+  }
+  
+  if is_object(.structured) {
+    protected = {}
+    for_each(object!(.structured)) -> |key, value| {
+      if includes(["request", "status"], key) || !(is_string(value) || is_integer(value) || is_float(value) || is_boolean(value)) {
+        protected = set!(protected, [key], value)
+      } else if is_string(value) {
+        protected = set!(protected, [key + "_str"], value)
+      } else if is_boolean(value) {
+        protected = set!(protected, [key + "_bool"], value)
+      } else {
+        protected = set!(protected, [key + "_num"], value)
+      }
+    }
+    .structured = protected
+  }
+'''
+`,
+		}),
+		Entry("Stringify undeclared structured fields", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication, logging.InputNameInfrastructure},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+						Parser: &logging.PipelineParserSpec{
+							Type:        logging.ParserTypeLogfmt,
+							TargetField: "fields",
+						},
+						StructuredFields: &logging.StructuredFieldsSpec{
+							Conflicts: logging.StructuredFieldConflictsStringify,
+						},
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.app = '!((starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube"))'
+route.infra = '(starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube")'
+
+# Set log_type to "application"
+[transforms.application]
+type = "remap"
+inputs = ["route_container_logs.app"]
+source = '''
+  .log_type = "application"
+'''
+
+# Set log_type to "infrastructure"
+[transforms.infrastructure]
+type = "remap"
+inputs = ["route_container_logs.infra","journal_logs"]
+source = '''
+  .log_type = "infrastructure"
+'''
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["application","infrastructure"]
+source = '''
+  parsed, err = parse_logfmt(.message)
+  if err == null {
+    .fields = parsed
+    del(.message)
+  }
+  
+  if is_object(.fields) {
+    protected = {}
+    for_each(object!(.fields)) -> |key, value| {
+      if !(is_string(value) || is_integer(value) || is_float(value) || is_boolean(value)) {
+        protected = set!(protected, [key], value)
+      } else {
+        protected = set!(protected, [key], to_string(value) ?? value)
+      }
+    }
+    .fields = protected
+  }
+'''
+`,
+		}),
+		Entry("Extract timestamp from a parsed field with a format and timezone", helpers.ConfGenerateTest{
 			CLFSpec: logging.ClusterLogForwarderSpec{
 				Pipelines: []logging.PipelineSpec{
 					{
@@ -291,6 +1056,11 @@ source = '''
 						OutputRefs: []string{logging.OutputNameDefault},
 						Name:       "pipeline",
 						Parse:      "json",
+						Timestamp: &logging.TimestampSpec{
+							Field:    "structured.time",
+							Format:   "%d/%b/%Y:%H:%M:%S",
+							Timezone: "Europe/Paris",
+						},
 					},
 				},
 			},
@@ -325,6 +1095,72 @@ source = '''
   if err == null {
     .structured = parsed
   }
+  
+  ts = .structured.time
+  if ts != null {
+    timestamp, err = parse_timestamp(to_string(ts) ?? "", format: "%d/%b/%Y:%H:%M:%S", timezone: "Europe/Paris")
+    if err == null {
+      ."@timestamp" = timestamp
+    } else {
+      .unparsed_timestamp = ts
+    }
+  }
+'''
+`,
+		}),
+		Entry("Extract RFC 3339 timestamp captured from the message", helpers.ConfGenerateTest{
+			CLFSpec: logging.ClusterLogForwarderSpec{
+				Pipelines: []logging.PipelineSpec{
+					{
+						InputRefs:  []string{logging.InputNameApplication, logging.InputNameInfrastructure},
+						OutputRefs: []string{logging.OutputNameDefault},
+						Name:       "pipeline",
+						Timestamp: &logging.TimestampSpec{
+							Pattern: `^\[(?<timestamp>[^\]]+)\]`,
+						},
+					},
+				},
+			},
+			ExpectedConf: `
+[transforms.route_container_logs]
+type = "route"
+inputs = ["container_logs"]
+route.app = '!((starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube"))'
+route.infra = '(starts_with!(.kubernetes.namespace_name,"kube-")) || (starts_with!(.kubernetes.namespace_name,"openshift-")) || (.kubernetes.namespace_name == "default") || (.kubernetes.namespace_name == "openshift") || (.kubernetes.namespace_name == "kube")'
+
+# Set log_type to "application"
+[transforms.application]
+type = "remap"
+inputs = ["route_container_logs.app"]
+source = '''
+  .log_type = "application"
+'''
+
+# Set log_type to "infrastructure"
+[transforms.infrastructure]
+type = "remap"
+inputs = ["route_container_logs.infra","journal_logs"]
+source = '''
+  .log_type = "infrastructure"
+'''
+
+[transforms.pipeline]
+type = "remap"
+inputs = ["application","infrastructure"]
+source = '''
+  ts = null
+  parsed, err = parse_regex(.message, r'^\[(?P<timestamp>[^\]]+)\]')
+  if err == null {
+    ts = parsed.timestamp
+  }
+  if ts != null {
+    timestamp, err = to_timestamp(ts)
+    if err == null {
+      ."@timestamp" = timestamp
+    } else {
+      .unparsed_timestamp = ts
+    }
+  }
 '''
 `,
 		}),
@@ -0,0 +1,70 @@
+package vector
+
+import (
+	"fmt"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
+)
+
+var coercions = map[logging.FieldType]string{
+	logging.FieldTypeString:    "to_string",
+	logging.FieldTypeInteger:   "to_int",
+	logging.FieldTypeFloat:     "to_float",
+	logging.FieldTypeBoolean:   "to_bool",
+	logging.FieldTypeTimestamp: "to_timestamp",
+}
+
+// CoerceStructuredFields returns the VRL coercing the declared structured fields to their type,
+// fields which cannot be coerced are removed
+func CoerceStructuredFields(target string, spec *logging.StructuredFieldsSpec) string {
+	vrls := []string{}
+	for _, path := range genhelper.DeclaredFields(spec) {
+		field := output.FieldPath(target + "." + path)
+		vrls = append(vrls, fmt.Sprintf(`if exists(%[1]s) {
+  %[1]s, err = %[2]s(%[1]s)
+  if err != null {
+    del(%[1]s)
+  }
+}`, field, coercions[spec.Types[path]]))
+	}
+	return strings.Join(vrls, "\n")
+}
+
+// ProtectStructuredFields returns the VRL protecting the undeclared top level structured fields from type conflicts
+// by suffixing their name with the type of their value or by converting their value to a string. Null, object and
+// array values are kept as is.
+func ProtectStructuredFields(target string, spec *logging.StructuredFieldsSpec) string {
+	field := output.FieldPath(target)
+	keep := "!(is_string(value) || is_integer(value) || is_float(value) || is_boolean(value))"
+	if fields := genhelper.DeclaredTopLevelFields(spec); len(fields) > 0 {
+		quoted := []string{}
+		for _, f := range fields {
+			quoted = append(quoted, fmt.Sprintf("%q", f))
+		}
+		keep = fmt.Sprintf("includes([%s], key) || %s", strings.Join(quoted, ", "), keep)
+	}
+	protect := `} else if is_string(value) {
+      protected = set!(protected, [key + "_str"], value)
+    } else if is_boolean(value) {
+      protected = set!(protected, [key + "_bool"], value)
+    } else {
+      protected = set!(protected, [key + "_num"], value)
+    }`
+	if spec.Conflicts == logging.StructuredFieldConflictsStringify {
+		protect = `} else {
+      protected = set!(protected, [key], to_string(value) ?? value)
+    }`
+	}
+	return fmt.Sprintf(`if is_object(%[1]s) {
+  protected = {}
+  for_each(object!(%[1]s)) -> |key, value| {
+    if %[2]s {
+      protected = set!(protected, [key], value)
+    %[3]s
+  }
+  %[1]s = protected
+}`, field, keep, protect)
+}
@@ -0,0 +1,41 @@
+package vector
+
+import (
+	"fmt"
+	"strings"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator/vector/output"
+)
+
+// ExtractTimestamp returns the VRL setting the @timestamp of records from their content,
+// records keep their collection time and record the unparsed time when it cannot be parsed
+func ExtractTimestamp(spec *logging.TimestampSpec) string {
+	vrls := []string{}
+	if spec.Field != "" {
+		vrls = append(vrls, fmt.Sprintf("ts = %s", output.FieldPath(spec.Field)))
+	} else {
+		vrls = append(vrls, fmt.Sprintf(`ts = null
+parsed, err = parse_regex(.message, r'%s')
+if err == null {
+  ts = parsed.timestamp
+}`, RegexLiteral(spec.Pattern)))
+	}
+	parse := "to_timestamp(ts)"
+	if spec.Format != "" {
+		args := []string{"to_string(ts) ?? \"\"", fmt.Sprintf("format: %q", spec.Format)}
+		if spec.Timezone != "" {
+			args = append(args, fmt.Sprintf("timezone: %q", spec.Timezone))
+		}
+		parse = fmt.Sprintf("parse_timestamp(%s)", strings.Join(args, ", "))
+	}
+	vrls = append(vrls, fmt.Sprintf(`if ts != null {
+  timestamp, err = %s
+  if err == null {
+    ."@timestamp" = timestamp
+  } else {
+    %s = ts
+  }
+}`, parse, output.FieldPath(logging.UnparsedTimestampField)))
+	return strings.Join(vrls, "\n")
+}
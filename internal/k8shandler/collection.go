@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/retry"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
@@ -101,6 +102,11 @@ func (clusterRequest *ClusterLoggingRequest) CreateOrUpdateCollection() (err err
 			return
 		}
 
+		if err = clusterRequest.reconcileReceiverServices(); err != nil {
+			log.V(9).Error(err, "clusterRequest.reconcileReceiverServices")
+			return
+		}
+
 		if err = clusterRequest.reconcileCollectorServiceMonitor(); err != nil {
 			log.V(9).Error(err, "clusterRequest.reconcileCollectorServiceMonitor")
 			return
@@ -120,6 +126,11 @@ func (clusterRequest *ClusterLoggingRequest) CreateOrUpdateCollection() (err err
 			return
 		}
 
+		if err = clusterRequest.reconcileEventRouter(); err != nil {
+			log.V(9).Error(err, "clusterRequest.reconcileEventRouter")
+			return
+		}
+
 		if err = clusterRequest.UpdateCollectorStatus(collectorType); err != nil {
 			log.V(9).Error(err, "unable to update status for the collector")
 		}
@@ -142,6 +153,14 @@ func (clusterRequest *ClusterLoggingRequest) CreateOrUpdateCollection() (err err
 		if err = clusterRequest.removeCollector(constants.CollectorName); err != nil {
 			return
 		}
+
+		if err = clusterRequest.removeEventRouter(); err != nil {
+			return
+		}
+
+		if err = clusterRequest.removeReceiverServices(sets.NewString()); err != nil {
+			return
+		}
 	}
 
 	return nil
@@ -0,0 +1,32 @@
+package k8shandler
+
+import (
+	"context"
+
+	log "github.com/ViaQ/logerr/v2/log/static"
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/eventrouter"
+	"github.com/openshift/cluster-logging-operator/internal/generator"
+)
+
+// reconcileEventRouter deploys the event router when a pipeline uses an events input and removes it otherwise
+func (clusterRequest *ClusterLoggingRequest) reconcileEventRouter() error {
+	if !generator.GatherSources(&clusterRequest.ForwarderSpec, nil).Has(logging.InputNameEvents) {
+		return clusterRequest.removeEventRouter()
+	}
+	cf := eventrouter.NewConfig(clusterRequest.Cluster)
+	// The event router is scheduled like the collector
+	if collection := clusterRequest.Cluster.Spec.Collection; collection != nil {
+		cf.NodeSelector = collection.NodeSelector
+		cf.Tolerations = collection.Tolerations
+	}
+	r := eventrouter.NewReconciler(clusterRequest.Client, cf)
+	log.V(3).Info("Enabling event router", "created-by", r.CreatedBy())
+	return r.Reconcile(context.TODO())
+}
+
+func (clusterRequest *ClusterLoggingRequest) removeEventRouter() error {
+	r := eventrouter.NewReconciler(clusterRequest.Client, eventrouter.NewConfig(clusterRequest.Cluster))
+	log.V(3).Info("Removing event router", "created-by", r.CreatedBy())
+	return r.Delete(context.TODO())
+}
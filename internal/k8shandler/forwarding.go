@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
 	"strings"
+	"time"
 
 	forwardergenerator "github.com/openshift/cluster-logging-operator/internal/generator/forwarder"
 	"github.com/openshift/cluster-logging-operator/internal/generator/helpers"
@@ -12,7 +16,9 @@ import (
 	log "github.com/ViaQ/logerr/v2/log/static"
 	configv1 "github.com/openshift/api/config/v1"
 	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/collector"
 	"github.com/openshift/cluster-logging-operator/internal/constants"
+	"github.com/openshift/cluster-logging-operator/internal/eventrouter"
 	"github.com/openshift/cluster-logging-operator/internal/generator"
 	"github.com/openshift/cluster-logging-operator/internal/generator/fluentd/output/cloudwatch"
 	"github.com/openshift/cluster-logging-operator/internal/generator/fluentd/output/security"
@@ -20,6 +26,7 @@ import (
 	"github.com/openshift/cluster-logging-operator/internal/url"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -66,6 +73,43 @@ func (clusterRequest *ClusterLoggingRequest) generateCollectorConfig() (config s
 	if debug, ok := clusterRequest.ForwarderRequest.Annotations[AnnotationDebugOutput]; ok && strings.ToLower(debug) == "true" {
 		op[helpers.EnableDebugOutput] = "true"
 	}
+	if clusterRequest.hasOutputType(logging.OutputTypeGoogleCloudLogging) {
+		if clusterName, err := clusterRequest.readClusterName(); err == nil {
+			op[generator.ClusterName] = clusterName
+		} else {
+			log.V(3).Error(err, "Unable to read the cluster name for monitored resource labels")
+		}
+		if location, err := clusterRequest.readClusterLocation(); err == nil {
+			op[generator.ClusterLocation] = location
+		} else {
+			log.V(3).Error(err, "Unable to read the cluster location for monitored resource labels")
+		}
+	}
+	if clusterRequest.ForwarderSpec.ClusterIdentity {
+		if clusterName, err := clusterRequest.readClusterName(); err == nil {
+			op[generator.ClusterName] = clusterName
+		} else {
+			log.V(3).Error(err, "Unable to read the cluster name for the cluster identity")
+		}
+		if clusterID, err := clusterRequest.readClusterID(); err == nil {
+			op[generator.ClusterID] = clusterID
+		} else {
+			log.V(3).Error(err, "Unable to read the cluster ID for the cluster identity")
+		}
+	}
+
+	if clusterRequest.Cluster != nil && generator.GatherSources(&clusterRequest.ForwarderSpec, op).Has(logging.InputNameEvents) {
+		cf := eventrouter.NewConfig(clusterRequest.Cluster)
+		op[generator.EventRouterNamespace] = cf.Namespace()
+	}
+
+	if helpers.HasAnnotatedFiles(&clusterRequest.ForwarderSpec) {
+		if files, err := clusterRequest.readPodFiles(); err == nil {
+			op[generator.PodFiles] = files
+		} else {
+			log.V(3).Error(err, "Unable to read the files listed by pod annotations")
+		}
+	}
 
 	var collectorType = clusterRequest.Cluster.Spec.Collection.Type
 	g := forwardergenerator.New(collectorType)
@@ -118,6 +162,39 @@ func (clusterRequest *ClusterLoggingRequest) readClusterName() (string, error) {
 	return infra.Status.InfrastructureName, nil
 }
 
+// readClusterLocation returns the region of the cluster on GCP, or "" on other platforms
+func (clusterRequest *ClusterLoggingRequest) readClusterLocation() (string, error) {
+	infra := configv1.Infrastructure{}
+	err := clusterRequest.Client.Get(context.Background(), client.ObjectKey{Name: constants.ClusterInfrastructureInstance}, &infra)
+	if err != nil {
+		return "", err
+	}
+	if infra.Status.PlatformStatus == nil || infra.Status.PlatformStatus.GCP == nil {
+		return "", nil
+	}
+	return infra.Status.PlatformStatus.GCP.Region, nil
+}
+
+func (clusterRequest *ClusterLoggingRequest) readClusterID() (string, error) {
+	version := configv1.ClusterVersion{}
+	err := clusterRequest.Client.Get(context.Background(), client.ObjectKey{Name: constants.ClusterVersionInstance}, &version)
+	if err != nil {
+		return "", err
+	}
+
+	return string(version.Spec.ClusterID), nil
+}
+
+// hasOutputType returns true if any of the normalized outputs is of type outputType
+func (clusterRequest *ClusterLoggingRequest) hasOutputType(outputType string) bool {
+	for _, o := range clusterRequest.ForwarderSpec.Outputs {
+		if o.Type == outputType {
+			return true
+		}
+	}
+	return false
+}
+
 // NormalizeForwarder normalizes the clusterRequest.ForwarderSpec, returns a normalized spec and status.
 func (clusterRequest *ClusterLoggingRequest) NormalizeForwarder() (*logging.ClusterLogForwarderSpec, *logging.ClusterLogForwarderStatus) {
 	clusterRequest.OutputSecrets = make(map[string]*corev1.Secret, len(clusterRequest.ForwarderSpec.Outputs))
@@ -147,9 +224,40 @@ func (clusterRequest *ClusterLoggingRequest) NormalizeForwarder() (*logging.Clus
 		clusterRequest.ForwarderSpec.Pipelines = pipelines
 	}
 
-	spec := &logging.ClusterLogForwarderSpec{}
+	spec := &logging.ClusterLogForwarderSpec{
+		AllowParserAnnotations: clusterRequest.ForwarderSpec.AllowParserAnnotations,
+		ClusterIdentity:        clusterRequest.ForwarderSpec.ClusterIdentity,
+	}
 	status := &logging.ClusterLogForwarderStatus{}
 
+	levelDetectionErr := verifyLevelDetection(clusterRequest.ForwarderSpec.LevelDetection)
+	if levelDetectionErr != nil {
+		log.V(3).Info("Level detection invalid, using the default level detection", "reason", levelDetectionErr)
+	} else {
+		spec.LevelDetection = clusterRequest.ForwarderSpec.LevelDetection
+	}
+
+	staticFieldsErr := verifyStaticFields(clusterRequest.ForwarderSpec.StaticFields)
+	if staticFieldsErr != nil {
+		log.V(3).Info("Static fields invalid, ignoring them", "reason", staticFieldsErr)
+	} else {
+		spec.StaticFields = clusterRequest.ForwarderSpec.StaticFields
+	}
+
+	enrichmentErr := verifyEnrichment(clusterRequest.ForwarderSpec.Enrichment, clusterRequest.outputCollectorType())
+	if enrichmentErr != nil {
+		log.V(3).Info("Enrichment invalid, attaching the default kubernetes metadata", "reason", enrichmentErr)
+	} else {
+		spec.Enrichment = clusterRequest.ForwarderSpec.Enrichment
+	}
+
+	hostAuditErr := verifyHostAudit(clusterRequest.ForwarderSpec.HostAudit, clusterRequest.outputCollectorType())
+	if hostAuditErr != nil {
+		log.V(3).Info("Host audit invalid, ignoring it", "reason", hostAuditErr)
+	} else {
+		spec.HostAudit = clusterRequest.ForwarderSpec.HostAudit
+	}
+
 	clusterRequest.verifyInputs(spec, status)
 	if !status.Inputs.IsAllReady() {
 		log.V(3).Info("Input not Ready", "inputs", status.Inputs)
@@ -162,6 +270,7 @@ func (clusterRequest *ClusterLoggingRequest) NormalizeForwarder() (*logging.Clus
 	if !status.Pipelines.IsAllReady() {
 		log.V(3).Info("Pipeline not Ready", "pipelines", status.Pipelines)
 	}
+	logMetricsErr := clusterRequest.verifyLogMetrics(spec)
 
 	routes := logging.NewRoutes(spec.Pipelines) // Compute used inputs/outputs
 
@@ -192,6 +301,21 @@ func (clusterRequest *ClusterLoggingRequest) NormalizeForwarder() (*logging.Clus
 		}
 		status.Conditions.SetCondition(condReady)
 	}
+	if levelDetectionErr != nil {
+		status.Conditions.SetCondition(condDegraded(logging.ReasonInvalid, "invalid level detection: %v", levelDetectionErr))
+	}
+	if enrichmentErr != nil {
+		status.Conditions.SetCondition(condDegraded(logging.ReasonInvalid, "invalid enrichment: %v", enrichmentErr))
+	}
+	if staticFieldsErr != nil {
+		status.Conditions.SetCondition(condDegraded(logging.ReasonInvalid, "invalid static fields: %v", staticFieldsErr))
+	}
+	if logMetricsErr != nil {
+		status.Conditions.SetCondition(condDegraded(logging.ReasonInvalid, "invalid log metrics: %v", logMetricsErr))
+	}
+	if hostAuditErr != nil {
+		status.Conditions.SetCondition(condDegraded(logging.ReasonInvalid, "invalid host audit: %v", hostAuditErr))
+	}
 
 	return spec, status
 }
@@ -268,6 +392,18 @@ func (clusterRequest *ClusterLoggingRequest) verifyPipelines(spec *logging.Clust
 				status.Pipelines.Set(pipeline.Name, condDegraded(logging.ReasonInvalid, "invalid: %v", msg))
 			}
 		}
+		if err := verifyParser(pipeline.Parser, clusterRequest.outputCollectorType()); err != nil {
+			status.Pipelines.Set(pipeline.Name, condInvalid("invalid parser: %v", err))
+			continue
+		}
+		if err := verifyStructuredFields(pipeline.StructuredFields); err != nil {
+			status.Pipelines.Set(pipeline.Name, condInvalid("invalid structured fields: %v", err))
+			continue
+		}
+		if err := verifyTimestamp(pipeline.Timestamp); err != nil {
+			status.Pipelines.Set(pipeline.Name, condInvalid("invalid timestamp: %v", err))
+			continue
+		}
 		status.Pipelines.Set(pipeline.Name, condReady) // Ready, possibly degraded.
 		spec.Pipelines = append(spec.Pipelines, logging.PipelineSpec{
 			Name:                  pipeline.Name,
@@ -275,15 +411,373 @@ func (clusterRequest *ClusterLoggingRequest) verifyPipelines(spec *logging.Clust
 			OutputRefs:            goodOut.List(),
 			Labels:                pipeline.Labels,
 			Parse:                 pipeline.Parse,
+			Parser:                pipeline.Parser,
+			StructuredFields:      pipeline.StructuredFields,
+			Timestamp:             pipeline.Timestamp,
 			DetectMultilineErrors: pipeline.DetectMultilineErrors,
 		})
 	}
 }
 
+// verifyParser returns an error if the parser of a pipeline cannot be generated for the collector
+func verifyParser(parser *logging.PipelineParserSpec, collectorType logging.LogCollectionType) error {
+	if parser == nil {
+		return nil
+	}
+	switch parser.Type {
+	case logging.ParserTypeLogfmt:
+		if collectorType != logging.LogCollectionTypeVector {
+			return fmt.Errorf("%s is only supported by the %s collector", parser.Type, logging.LogCollectionTypeVector)
+		}
+	case logging.ParserTypeRegex:
+		if parser.Pattern == "" {
+			return fmt.Errorf("%s requires a pattern", parser.Type)
+		}
+		re, err := regexp.Compile(strings.ReplaceAll(parser.Pattern, "(?<", "(?P<"))
+		if err != nil {
+			return err
+		}
+		named := false
+		for _, name := range re.SubexpNames() {
+			named = named || name != ""
+		}
+		if !named {
+			return fmt.Errorf("pattern %q has no named capture group", parser.Pattern)
+		}
+	case logging.ParserTypeCSV:
+		if len(parser.Headers) == 0 {
+			return fmt.Errorf("%s requires headers", parser.Type)
+		}
+	}
+	return nil
+}
+
+// verifyTimestamp returns an error if a timestamp has neither or both a field and a pattern,
+// a pattern without a `timestamp` named capture group, or a timezone which is unknown or set without a format
+func verifyTimestamp(spec *logging.TimestampSpec) error {
+	if spec == nil {
+		return nil
+	}
+	switch {
+	case spec.Field == "" && spec.Pattern == "":
+		return errors.New("a field or a pattern is required")
+	case spec.Field != "" && spec.Pattern != "":
+		return errors.New("only one of field or pattern is allowed")
+	case spec.Pattern != "":
+		re, err := regexp.Compile(strings.ReplaceAll(spec.Pattern, "(?<", "(?P<"))
+		if err != nil {
+			return err
+		}
+		if re.SubexpIndex("timestamp") < 0 {
+			return fmt.Errorf("pattern %q has no `timestamp` named capture group", spec.Pattern)
+		}
+	}
+	if spec.Timezone != "" {
+		if spec.Format == "" {
+			return errors.New("a timezone requires a format")
+		}
+		if _, err := time.LoadLocation(spec.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", spec.Timezone)
+		}
+	}
+	return nil
+}
+
+// verifyLevelDetection returns an error if a level detection rule has neither or both a field and a pattern,
+// or a pattern without a level which has no `level` named capture group
+func verifyLevelDetection(spec *logging.LevelDetectionSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if len(spec.Rules) == 0 {
+		return errors.New("at least one rule is required")
+	}
+	for i, rule := range spec.Rules {
+		switch {
+		case rule.Field == "" && rule.Pattern == "":
+			return fmt.Errorf("rule %d requires a field or a pattern", i)
+		case rule.Field != "" && (rule.Pattern != "" || rule.Level != ""):
+			return fmt.Errorf("rule %d has a field, it cannot have a pattern or a level", i)
+		case rule.Pattern != "":
+			re, err := regexp.Compile(strings.ReplaceAll(rule.Pattern, "(?<", "(?P<"))
+			if err != nil {
+				return fmt.Errorf("rule %d: %v", i, err)
+			}
+			if rule.Level == "" && re.SubexpIndex("level") < 0 {
+				return fmt.Errorf("rule %d requires a level or a pattern with a `level` named capture group", i)
+			}
+		}
+	}
+	return nil
+}
+
+// verifyEnrichment returns an error if the enrichment selects metadata not supported by the collector
+// or an empty node label key
+func verifyEnrichment(spec *logging.EnrichmentSpec, collectorType logging.LogCollectionType) error {
+	if spec == nil {
+		return nil
+	}
+	if collectorType != logging.LogCollectionTypeVector {
+		if spec.Workload {
+			return fmt.Errorf("workload is only supported by the %s collector", logging.LogCollectionTypeVector)
+		}
+		if len(spec.NodeLabels) != 0 {
+			return fmt.Errorf("node labels are only supported by the %s collector", logging.LogCollectionTypeVector)
+		}
+	}
+	for _, key := range spec.NodeLabels {
+		if key == "" {
+			return errors.New("node label keys must not be empty")
+		}
+	}
+	return nil
+}
+
+// verifyHostAudit returns an error if the processing of linux audit logs is not supported by the collector
+// or the correlation window is out of range
+func verifyHostAudit(spec *logging.HostAuditSpec, collectorType logging.LogCollectionType) error {
+	if spec == nil {
+		return nil
+	}
+	if collectorType != logging.LogCollectionTypeVector {
+		return fmt.Errorf("host audit is only supported by the %s collector", logging.LogCollectionTypeVector)
+	}
+	if spec.CorrelationWindow < 0 || spec.CorrelationWindow > 60 {
+		return fmt.Errorf("correlation window must be between 1 and 60 seconds, got %d", spec.CorrelationWindow)
+	}
+	return nil
+}
+
+// verifyJournal returns an error if the journal filter of an infrastructure input referenced by pipelines differs
+// from the filters of the other referenced infrastructure inputs, since the journal is read once per node
+func verifyJournal(name string, spec logging.ClusterLogForwarderSpec) error {
+	routes := logging.NewRoutes(spec.Pipelines)
+	if _, ok := routes.ByInput[name]; !ok {
+		return nil
+	}
+	if _, ok := routes.ByInput[logging.InputNameInfrastructure]; ok {
+		return fmt.Errorf("cannot be referenced along with the %q input collecting the whole journal", logging.InputNameInfrastructure)
+	}
+	var journal *logging.Journal
+	for _, input := range spec.Inputs {
+		if input.Name == name && input.Infrastructure != nil {
+			journal = input.Infrastructure.Journal
+		}
+	}
+	for _, input := range spec.Inputs {
+		if _, ok := routes.ByInput[input.Name]; !ok || input.Name == name || input.Infrastructure == nil {
+			continue
+		}
+		if !reflect.DeepEqual(journal, input.Infrastructure.Journal) {
+			return fmt.Errorf("must be the same as the journal filter of input %q", input.Name)
+		}
+	}
+	return nil
+}
+
+// verifyFileInput returns an error if a file input may read files outside of the allowed directories
+// or has an invalid multiline pattern
+func verifyFileInput(file *logging.File) error {
+	if file == nil {
+		return nil
+	}
+	if len(file.Paths) == 0 {
+		return errors.New("paths must not be empty")
+	}
+	for _, path := range file.Paths {
+		if err := helpers.VerifyFileInputPath(path); err != nil {
+			return err
+		}
+	}
+	for _, path := range file.Excludes {
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("exclude %q must be absolute", path)
+		}
+	}
+	if file.MultilineStartPattern != "" {
+		if _, err := regexp.Compile(file.MultilineStartPattern); err != nil {
+			return fmt.Errorf("invalid multiline start pattern: %v", err)
+		}
+	}
+	return nil
+}
+
+// verifyReceiver returns an error if a receiver input can not be exposed by the collector or its client secret is
+// missing. The client secret is retained for generation.
+func (clusterRequest *ClusterLoggingRequest) verifyReceiver(name string, receiver *logging.ReceiverSpec, ports map[int32]string) error {
+	if errs := validation.IsDNS1035Label(helpers.ReceiverServiceName(name)); len(errs) > 0 {
+		return fmt.Errorf("service name %q is invalid: %s", helpers.ReceiverServiceName(name), strings.Join(errs, ", "))
+	}
+	switch receiver.Type {
+	case logging.ReceiverTypeHTTP, logging.ReceiverTypeSyslog, logging.ReceiverTypeKubeAPIAudit:
+	default:
+		return fmt.Errorf("unsupported type %q", receiver.Type)
+	}
+	if receiver.KubeAPIAudit != nil && receiver.Type != logging.ReceiverTypeKubeAPIAudit {
+		return fmt.Errorf("kubeAPIAudit settings are not supported by the %s receiver", receiver.Type)
+	}
+	if receiver.Port < 1024 || receiver.Port > 65535 {
+		return fmt.Errorf("port %d must be between 1024 and 65535", receiver.Port)
+	}
+	if collector.IsReservedPort(receiver.Port) {
+		return fmt.Errorf("port %d is reserved by the collector", receiver.Port)
+	}
+	if other, found := ports[receiver.Port]; found {
+		return fmt.Errorf("port %d is already used by input %q", receiver.Port, other)
+	}
+	if receiver.Type == logging.ReceiverTypeSyslog {
+		switch {
+		case receiver.Secret == nil:
+			return nil
+		case helpers.IsUDPReceiver(receiver):
+			return errors.New("secret is not supported by the syslog receiver over udp")
+		}
+	}
+	if receiver.Secret == nil || receiver.Secret.Name == "" {
+		return errors.New("secret must have a name")
+	}
+	secret, err := clusterRequest.GetSecret(receiver.Secret.Name)
+	if err != nil {
+		return fmt.Errorf("secret %q not found", receiver.Secret.Name)
+	}
+	if len(secret.Data[constants.TrustedCABundleKey]) == 0 {
+		return fmt.Errorf("secret %q must have a %v key", receiver.Secret.Name, constants.TrustedCABundleKey)
+	}
+	clusterRequest.OutputSecrets[helpers.ReceiverSecretKey(name)] = secret
+	return nil
+}
+
+// verifyLogMetrics adds the valid log metrics measuring the records of ready pipelines to spec, and returns an error
+// listing the invalid ones
+func (clusterRequest *ClusterLoggingRequest) verifyLogMetrics(spec *logging.ClusterLogForwarderSpec) error {
+	pipelines := sets.NewString()
+	for _, p := range spec.Pipelines {
+		pipelines.Insert(p.Name)
+	}
+	names := sets.NewString()
+	invalid := []string{}
+	for _, m := range clusterRequest.ForwarderSpec.LogMetrics {
+		err := verifyLogMetric(m, pipelines, clusterRequest.outputCollectorType())
+		if err == nil && names.Has(m.Name) {
+			err = fmt.Errorf("duplicate name %q", m.Name)
+		}
+		if err != nil {
+			log.V(3).Info("Log metric invalid, ignoring it", "name", m.Name, "reason", err)
+			invalid = append(invalid, fmt.Sprintf("%s: %v", m.Name, err))
+			continue
+		}
+		names.Insert(m.Name)
+		spec.LogMetrics = append(spec.LogMetrics, m)
+	}
+	if len(invalid) > 0 {
+		return errors.New(strings.Join(invalid, "; "))
+	}
+	return nil
+}
+
+var metricName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// unboundedFields have a distinct value for almost every record or pod, and would create a series per value when
+// used as metric labels
+var unboundedFields = sets.NewString(
+	"message",
+	"@timestamp",
+	"timestamp",
+	"time",
+	"viaq_msg_id",
+	"file",
+	"openshift.sequence",
+	"kubernetes.pod_id",
+	"kubernetes.pod_name",
+	"kubernetes.container_id",
+)
+
+// verifyLogMetric returns an error if a log metric is not supported by the collector, measures unknown pipelines
+// or does not define a valid value, pattern or labels
+func verifyLogMetric(m logging.LogMetricSpec, pipelines sets.String, collectorType logging.LogCollectionType) error {
+	if collectorType != logging.LogCollectionTypeVector {
+		return fmt.Errorf("log metrics are only supported by the %s collector", logging.LogCollectionTypeVector)
+	}
+	if !metricName.MatchString(m.Name) {
+		return fmt.Errorf("name %q is not a valid metric name", m.Name)
+	}
+	if len(m.PipelineRefs) == 0 {
+		return errors.New("no pipelines are referenced")
+	}
+	for _, ref := range m.PipelineRefs {
+		if !pipelines.Has(ref) {
+			return fmt.Errorf("unknown or not ready pipeline %q", ref)
+		}
+	}
+	switch m.Type {
+	case logging.LogMetricTypeCounter:
+	case logging.LogMetricTypeHistogram:
+		if m.Field == "" {
+			return errors.New("histograms must define the observed field")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", m.Type)
+	}
+	if m.Field != "" {
+		if err := verifyStaticFields(map[string]string{m.Field: ""}); err != nil {
+			return err
+		}
+	}
+	if m.Match != "" {
+		if _, err := regexp.Compile(m.Match); err != nil {
+			return fmt.Errorf("invalid match: %v", err)
+		}
+	}
+	for _, label := range sets.StringKeySet(m.Labels).List() {
+		path := m.Labels[label]
+		if !metricName.MatchString(label) {
+			return fmt.Errorf("label %q is not a valid label name", label)
+		}
+		if path == "" {
+			return fmt.Errorf("label %q has no field", label)
+		}
+		if err := verifyStaticFields(map[string]string{path: ""}); err != nil {
+			return err
+		}
+		if unboundedFields.Has(path) {
+			return fmt.Errorf("label %q uses field %q whose values are unbounded", label, path)
+		}
+	}
+	return nil
+}
+
+// verifyStaticFields returns an error if a static field is not a dot separated path
+func verifyStaticFields(fields map[string]string) error {
+	for path := range fields {
+		for _, segment := range strings.Split(path, ".") {
+			if segment == "" {
+				return fmt.Errorf("field %q has an empty path segment", path)
+			}
+		}
+	}
+	return nil
+}
+
+// verifyStructuredFields returns an error if a declared structured field is not a dot separated path
+func verifyStructuredFields(spec *logging.StructuredFieldsSpec) error {
+	if spec == nil {
+		return nil
+	}
+	for path := range spec.Types {
+		for _, segment := range strings.Split(path, ".") {
+			if segment == "" {
+				return fmt.Errorf("field %q has an empty path segment", path)
+			}
+		}
+	}
+	return nil
+}
+
 // verifyInputs and set status.Inputs conditions
 func (clusterRequest *ClusterLoggingRequest) verifyInputs(spec *logging.ClusterLogForwarderSpec, status *logging.ClusterLogForwarderStatus) {
 	// Collect input conditions
 	status.Inputs = logging.NamedConditions{}
+	receiverPorts := map[int32]string{}
 	for i, input := range clusterRequest.ForwarderSpec.Inputs {
 		i, input := i, input // Don't bind range variables.
 		badName := func(format string, args ...interface{}) {
@@ -297,6 +791,28 @@ func (clusterRequest *ClusterLoggingRequest) verifyInputs(spec *logging.ClusterL
 			badName("input name %q is reserved", input.Name)
 		case len(status.Inputs[input.Name]) > 0:
 			badName("duplicate name: %q", input.Name)
+		case input.Events != nil && clusterRequest.outputCollectorType() != logging.LogCollectionTypeVector:
+			status.Inputs.Set(input.Name, condInvalid("events input is only supported by the vector collector"))
+		case input.File != nil && clusterRequest.outputCollectorType() != logging.LogCollectionTypeVector:
+			status.Inputs.Set(input.Name, condInvalid("file input is only supported by the vector collector"))
+		case verifyFileInput(input.File) != nil:
+			status.Inputs.Set(input.Name, condInvalid("invalid file input: %v", verifyFileInput(input.File)))
+		case input.Infrastructure != nil && input.Infrastructure.Journal != nil && verifyJournal(input.Name, clusterRequest.ForwarderSpec) != nil:
+			status.Inputs.Set(input.Name, condInvalid("invalid journal filter: %v", verifyJournal(input.Name, clusterRequest.ForwarderSpec)))
+		case input.Audit != nil && input.Audit.Filter != nil && clusterRequest.outputCollectorType() != logging.LogCollectionTypeVector:
+			status.Inputs.Set(input.Name, condInvalid("audit filter is only supported by the vector collector"))
+		case input.Application != nil && input.Application.AnnotatedFiles && clusterRequest.outputCollectorType() != logging.LogCollectionTypeVector:
+			status.Inputs.Set(input.Name, condInvalid("annotated files are only supported by the vector collector"))
+		case input.Receiver != nil && clusterRequest.outputCollectorType() != logging.LogCollectionTypeVector:
+			status.Inputs.Set(input.Name, condInvalid("receiver input is only supported by the vector collector"))
+		case input.Receiver != nil:
+			if err := clusterRequest.verifyReceiver(input.Name, input.Receiver, receiverPorts); err != nil {
+				status.Inputs.Set(input.Name, condInvalid("invalid receiver input: %v", err))
+				break
+			}
+			receiverPorts[input.Receiver.Port] = input.Name
+			spec.Inputs = append(spec.Inputs, input)
+			status.Inputs.Set(input.Name, condReady)
 		default:
 			spec.Inputs = append(spec.Inputs, input)
 			status.Inputs.Set(input.Name, condReady)
@@ -353,6 +869,12 @@ func (clusterRequest *ClusterLoggingRequest) verifyOutputs(spec *logging.Cluster
 			log.V(3).Info("verifyOutputs failed", "reason", "output URL is invalid", "output URL", output.URL)
 		case !clusterRequest.verifyOutputSecret(&output, status.Outputs):
 			log.V(3).Info("verifyOutputs failed", "reason", "output secret is invalid")
+		case !clusterRequest.verifyOutputTuning(&output, status.Outputs):
+			log.V(3).Info("verifyOutputs failed", "reason", "output tuning is invalid", "output name", output.Name)
+		case !clusterRequest.verifyOutputFallback(&output, status.Outputs):
+			log.V(3).Info("verifyOutputs failed", "reason", "output fallback is invalid", "output name", output.Name)
+		case !clusterRequest.verifyOutputDataModel(&output, status.Outputs):
+			log.V(3).Info("verifyOutputs failed", "reason", "output data model is invalid", "output name", output.Name)
 		case output.Type == logging.OutputTypeCloudwatch && output.Cloudwatch == nil:
 			log.V(3).Info("verifyOutputs failed", "reason", "Cloudwatch output requires type spec", "output name", output.Name)
 			status.Outputs.Set(output.Name, condInvalid("output %q: Cloudwatch output requires type spec", output.Name))
@@ -413,6 +935,9 @@ func (clusterRequest *ClusterLoggingRequest) verifyOutputURL(output *logging.Out
 		conds.Set(output.Name, c)
 		return false
 	}
+	if len(output.URLs) > 0 {
+		return clusterRequest.verifyOutputURLs(output, conds)
+	}
 	if output.URL == "" {
 		// Some output types allow a missing URL
 		// TODO (alanconway) move output-specific valiation to the output implementation.
@@ -432,6 +957,141 @@ func (clusterRequest *ClusterLoggingRequest) verifyOutputURL(output *logging.Out
 	return true
 }
 
+// outputURLStrategies are the output types which support several URLs for each collector and strategy.
+// Only plugins which stop sending to an unavailable URL until it recovers are listed: the elasticsearch
+// client marks failed hosts dead, fluentd forward checks its servers with heartbeats. Spreading records
+// across independent sinks, as vector and fluentd out_roundrobin would, stalls on any unavailable URL.
+var outputURLStrategies = map[logging.LogCollectionType]map[logging.OutputURLStrategy]sets.String{
+	logging.LogCollectionTypeFluentd: {
+		logging.OutputURLStrategyRoundRobin: sets.NewString(
+			logging.OutputTypeElasticsearch,
+			logging.OutputTypeFluentdForward,
+		),
+		logging.OutputURLStrategyFailover: sets.NewString(logging.OutputTypeFluentdForward),
+	},
+}
+
+func (clusterRequest *ClusterLoggingRequest) verifyOutputURLs(output *logging.OutputSpec, conds logging.NamedConditions) bool {
+	fail := func(c status.Condition) bool {
+		conds.Set(output.Name, c)
+		return false
+	}
+	if output.URL != "" {
+		return fail(condInvalid("url and urls are mutually exclusive"))
+	}
+	collectorType := clusterRequest.outputCollectorType()
+	strategy := output.Strategy
+	if strategy == "" {
+		strategy = logging.OutputURLStrategyRoundRobin
+	}
+	if !outputURLStrategies[collectorType][strategy].Has(output.Type) {
+		return fail(condInvalid("urls with strategy %s are not supported by the %s collector for output type %v", strategy, collectorType, output.Type))
+	}
+	scheme := ""
+	for _, s := range output.URLs {
+		u, err := url.Parse(s)
+		if err != nil {
+			return fail(condInvalid("invalid URL: %v", err))
+		}
+		if err := url.CheckAbsolute(u); err != nil {
+			return fail(condInvalid("invalid URL: %v", err))
+		}
+		if scheme != "" && !strings.EqualFold(u.Scheme, scheme) {
+			return fail(condInvalid("urls must have the same scheme: %q", output.URLs))
+		}
+		scheme = u.Scheme
+	}
+	// Generators derive TLS and other settings from the first URL
+	output.URL = output.URLs[0]
+	return true
+}
+
+// outputCollectorType returns the collector type the outputs are verified for
+func (clusterRequest *ClusterLoggingRequest) outputCollectorType() logging.LogCollectionType {
+	if clusterRequest.Cluster != nil && clusterRequest.Cluster.Spec.Collection != nil && clusterRequest.Cluster.Spec.Collection.Type != "" {
+		return clusterRequest.Cluster.Spec.Collection.Type
+	}
+	return logging.LogCollectionTypeFluentd
+}
+
+// outputCompression is the compression supported by an output type for each collector
+var outputCompression = map[logging.LogCollectionType]map[string]sets.String{
+	logging.LogCollectionTypeFluentd: {
+		logging.OutputTypeElasticsearch:  sets.NewString("gzip"),
+		logging.OutputTypeFluentdForward: sets.NewString("gzip"),
+		logging.OutputTypeKafka:          sets.NewString("gzip", "snappy", "lz4", "zstd"),
+	},
+	logging.LogCollectionTypeVector: {
+		logging.OutputTypeElasticsearch: sets.NewString("gzip"),
+		logging.OutputTypeCloudwatch:    sets.NewString("gzip"),
+		logging.OutputTypeLoki:          sets.NewString("gzip"),
+		logging.OutputTypeKafka:         sets.NewString("gzip", "snappy", "lz4", "zstd"),
+	},
+}
+
+func (clusterRequest *ClusterLoggingRequest) verifyOutputTuning(output *logging.OutputSpec, conds logging.NamedConditions) bool {
+	fail := func(c status.Condition) bool {
+		conds.Set(output.Name, c)
+		return false
+	}
+	if output.Tuning == nil {
+		return true
+	}
+	collectorType := clusterRequest.outputCollectorType()
+	compression := output.Tuning.Compression
+	if compression != "" && compression != "none" && !outputCompression[collectorType][output.Type].Has(compression) {
+		return fail(condInvalid("compression %q is not supported by the %s collector for output type %v", compression, collectorType, output.Type))
+	}
+	if output.Tuning.Buffer != nil && collectorType != logging.LogCollectionTypeVector {
+		return fail(condInvalid("buffer is only supported by the %s collector", logging.LogCollectionTypeVector))
+	}
+	if buffer := output.Tuning.Buffer; buffer != nil && buffer.MaxSize != 0 {
+		if buffer.Type != logging.OutputBufferTypeDisk {
+			return fail(condInvalid("buffer maxSize is only supported by %s buffers", logging.OutputBufferTypeDisk))
+		}
+		if buffer.MaxSize < logging.MinDiskBufferMaxSize {
+			return fail(condInvalid("buffer maxSize must be at least %d bytes", logging.MinDiskBufferMaxSize))
+		}
+	}
+	return true
+}
+
+// verifyOutputFallback rejects fallback outputs: neither collector can route the records an output rejects
+// together with the error of the destination, vector sinks do not emit rejected records and the fluentd
+// <secondary> section receives undelivered chunks without the error.
+func (clusterRequest *ClusterLoggingRequest) verifyOutputFallback(output *logging.OutputSpec, conds logging.NamedConditions) bool {
+	if output.FallbackOutputRef == "" {
+		return true
+	}
+	conds.Set(output.Name, condInvalid("fallback output is not supported by the %s collector: records rejected by the output cannot be sent with the rejection reason", clusterRequest.outputCollectorType()))
+	return false
+}
+
+// dataModelOutputTypes are the output types which support data models other than ViaQ for each collector
+var dataModelOutputTypes = map[logging.LogCollectionType]sets.String{
+	logging.LogCollectionTypeFluentd: sets.NewString(
+		logging.OutputTypeElasticsearch,
+		logging.OutputTypeFluentdForward,
+		logging.OutputTypeKafka,
+	),
+	logging.LogCollectionTypeVector: sets.NewString(
+		logging.OutputTypeElasticsearch,
+		logging.OutputTypeKafka,
+	),
+}
+
+func (clusterRequest *ClusterLoggingRequest) verifyOutputDataModel(output *logging.OutputSpec, conds logging.NamedConditions) bool {
+	if output.DataModel == "" || output.DataModel == logging.DataModelViaQ {
+		return true
+	}
+	collectorType := clusterRequest.outputCollectorType()
+	if !dataModelOutputTypes[collectorType].Has(output.Type) {
+		conds.Set(output.Name, condInvalid("data model %s is not supported by the %s collector for output type %v", output.DataModel, collectorType, output.Type))
+		return false
+	}
+	return true
+}
+
 func (clusterRequest *ClusterLoggingRequest) verifyOutputSecret(output *logging.OutputSpec, conds logging.NamedConditions) bool {
 	fail := func(c status.Condition) bool {
 		conds.Set(output.Name, c)
@@ -9,6 +9,8 @@ import (
 	"github.com/openshift/cluster-logging-operator/internal/generator"
 	"github.com/openshift/cluster-logging-operator/internal/generator/forwarder"
 	forwardergenerator "github.com/openshift/cluster-logging-operator/internal/generator/forwarder"
+	"github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/utils"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -108,6 +110,30 @@ var _ = Describe("Normalizing forwarder", func() {
 			}
 		})
 
+		Context("inputs", func() {
+			BeforeEach(func() {
+				request.ForwarderSpec.Inputs = []logging.InputSpec{
+					{Name: "myEvents", Events: &logging.Events{Namespaces: []string{"myproject"}}},
+				}
+				request.ForwarderSpec.Pipelines[0].InputRefs = []string{logging.InputNameApplication, "myEvents"}
+			})
+
+			It("should accept an events input for the vector collector", func() {
+				cluster.Spec.Collection = &logging.CollectionSpec{Type: logging.LogCollectionTypeVector}
+				spec, status := request.NormalizeForwarder()
+				Expect(spec.Inputs).To(HaveLen(1))
+				Expect(status.Inputs["myEvents"]).To(HaveCondition("Ready", true, "", ""))
+			})
+
+			It("should reject an events input for the fluentd collector", func() {
+				spec, status := request.NormalizeForwarder()
+				Expect(spec.Inputs).To(BeEmpty())
+				Expect(status.Inputs).To(HaveKey(logging.InputNameApplication))
+				Expect(status.Inputs).ToNot(HaveKey("myEvents"))
+				Expect(status.Pipelines["aPipeline"]).To(HaveCondition("Degraded", true, logging.ReasonInvalid, "unrecognized inputs"))
+			})
+		})
+
 		Context("pipelines", func() {
 			It("should only include inputs if there is at least one valid pipeline", func() {
 				request.ForwarderSpec.Pipelines = []logging.PipelineSpec{
@@ -304,6 +330,46 @@ var _ = Describe("Normalizing forwarder", func() {
 				Expect(status.Outputs["aCloudwatch"]).To(HaveCondition("Ready", true, "", ""))
 			})
 
+			It("should keep the switch allowing parser annotations", func() {
+				request.ForwarderSpec.AllowParserAnnotations = true
+				spec, _ := request.NormalizeForwarder()
+				Expect(spec.AllowParserAnnotations).To(BeTrue())
+			})
+
+			It("should keep a valid level detection", func() {
+				request.ForwarderSpec.LevelDetection = &logging.LevelDetectionSpec{
+					Rules: []logging.LevelDetectionRule{{Field: "structured.severity"}},
+				}
+				spec, status := request.NormalizeForwarder()
+				Expect(spec.LevelDetection).To(Equal(request.ForwarderSpec.LevelDetection))
+				Expect(status.Conditions).To(HaveCondition("Ready", true, "", ""))
+			})
+
+			It("should use the default level detection and be degraded when the level detection is invalid", func() {
+				request.ForwarderSpec.LevelDetection = &logging.LevelDetectionSpec{
+					Rules: []logging.LevelDetectionRule{{Pattern: "error"}},
+				}
+				spec, status := request.NormalizeForwarder()
+				Expect(spec.LevelDetection).To(BeNil())
+				Expect(status.Conditions).To(HaveCondition("Degraded", true, "Invalid", "invalid level detection"))
+			})
+
+			It("should ignore invalid log metrics and be degraded", func() {
+				request.ForwarderSpec.LogMetrics = []logging.LogMetricSpec{
+					{Name: "records_total", Type: logging.LogMetricTypeCounter, PipelineRefs: []string{"unknown"}},
+				}
+				spec, status := request.NormalizeForwarder()
+				Expect(spec.LogMetrics).To(BeEmpty())
+				Expect(status.Conditions).To(HaveCondition("Degraded", true, "Invalid", "invalid log metrics"))
+			})
+
+			It("should ignore an invalid host audit and be degraded", func() {
+				request.ForwarderSpec.HostAudit = &logging.HostAuditSpec{Correlate: true, CorrelationWindow: -1}
+				spec, status := request.NormalizeForwarder()
+				Expect(spec.HostAudit).To(BeNil())
+				Expect(status.Conditions).To(HaveCondition("Degraded", true, "Invalid", "invalid host audit"))
+			})
+
 			It("should drop outputs that have secrets with no names", func() {
 				request.ForwarderSpec.Outputs = append(request.ForwarderSpec.Outputs, logging.OutputSpec{
 					Name:   "aName",
@@ -1122,3 +1188,847 @@ func TestClusterLoggingRequest_verifyGCL(t *testing.T) {
 		}
 	}
 }
+
+func TestClusterLoggingRequest_verifyGCLLogID(t *testing.T) {
+	for logID, valid := range map[string]bool{
+		"app-{.kubernetes.namespace_name}": true,
+		"app {.kubernetes.namespace_name}": false,
+		"app-{kubernetes.namespace_name}":  false,
+	} {
+		output := logging.OutputSpec{
+			Name: "X",
+			Type: "googleCloudLogging",
+			OutputTypeSpec: logging.OutputTypeSpec{
+				GoogleCloudLogging: &logging.GoogleCloudLogging{
+					ProjectID: "project1",
+					LogID:     logID,
+				},
+			},
+		}
+		clf := logging.ClusterLogForwarderSpec{
+			Pipelines: []logging.PipelineSpec{
+				{
+					InputRefs:  []string{logging.InputNameApplication},
+					OutputRefs: []string{"X"},
+				},
+			},
+			Outputs: []logging.OutputSpec{output},
+		}
+		g := forwardergenerator.New(logging.LogCollectionTypeVector)
+		err := g.Verify(nil, nil, &clf, generator.Options{})
+		if valid && err != nil {
+			t.Errorf("logId %q: want no error, got %v", logID, err)
+		}
+		if !valid && (err == nil || err.Error() != forwarder.ErrGCLLogID(output).Error()) {
+			t.Errorf("logId %q: want %v, got %v", logID, forwarder.ErrGCLLogID(output), err)
+		}
+	}
+}
+
+func TestClusterLoggingRequest_verifyOutputTuning(t *testing.T) {
+	clusterWith := func(collectorType logging.LogCollectionType) *logging.ClusterLogging {
+		return &logging.ClusterLogging{
+			Spec: logging.ClusterLoggingSpec{
+				Collection: &logging.CollectionSpec{Type: collectorType},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		cluster *logging.ClusterLogging
+		output  *logging.OutputSpec
+		want    bool
+	}{
+		{
+			name:    "Without tuning",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "syslog"},
+			want:    true,
+		},
+		{
+			name:    "With no compression",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output: &logging.OutputSpec{Name: "test-output", Type: "googleCloudLogging",
+				Tuning: &logging.OutputTuningSpec{Compression: "none", Concurrency: 2}},
+			want: true,
+		},
+		{
+			name:    "With vector elasticsearch gzip compression",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output: &logging.OutputSpec{Name: "test-output", Type: "elasticsearch",
+				Tuning: &logging.OutputTuningSpec{Compression: "gzip"}},
+			want: true,
+		},
+		{
+			name:    "With vector elasticsearch zstd compression",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output: &logging.OutputSpec{Name: "test-output", Type: "elasticsearch",
+				Tuning: &logging.OutputTuningSpec{Compression: "zstd"}},
+			want: false,
+		},
+		{
+			name:    "With vector fluentdForward gzip compression",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output: &logging.OutputSpec{Name: "test-output", Type: "fluentdForward",
+				Tuning: &logging.OutputTuningSpec{Compression: "gzip"}},
+			want: false,
+		},
+		{
+			name:    "With fluentd fluentdForward gzip compression",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output: &logging.OutputSpec{Name: "test-output", Type: "fluentdForward",
+				Tuning: &logging.OutputTuningSpec{Compression: "gzip"}},
+			want: true,
+		},
+		{
+			name:    "With fluentd kafka snappy compression",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output: &logging.OutputSpec{Name: "test-output", Type: "kafka",
+				Tuning: &logging.OutputTuningSpec{Compression: "snappy"}},
+			want: true,
+		},
+		{
+			name:    "With vector disk buffer",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output: &logging.OutputSpec{Name: "test-output", Type: "elasticsearch",
+				Tuning: &logging.OutputTuningSpec{Buffer: &logging.OutputBufferSpec{Type: logging.OutputBufferTypeDisk}}},
+			want: true,
+		},
+		{
+			name:    "With vector disk buffer max size",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output: &logging.OutputSpec{Name: "test-output", Type: "elasticsearch",
+				Tuning: &logging.OutputTuningSpec{Buffer: &logging.OutputBufferSpec{Type: logging.OutputBufferTypeDisk, MaxSize: 536870912}}},
+			want: true,
+		},
+		{
+			name:    "With vector disk buffer max size below the minimum",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output: &logging.OutputSpec{Name: "test-output", Type: "elasticsearch",
+				Tuning: &logging.OutputTuningSpec{Buffer: &logging.OutputBufferSpec{Type: logging.OutputBufferTypeDisk, MaxSize: 1048576}}},
+			want: false,
+		},
+		{
+			name:    "With vector memory buffer max size",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output: &logging.OutputSpec{Name: "test-output", Type: "elasticsearch",
+				Tuning: &logging.OutputTuningSpec{Buffer: &logging.OutputBufferSpec{MaxSize: 536870912}}},
+			want: false,
+		},
+		{
+			name:    "With fluentd disk buffer",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output: &logging.OutputSpec{Name: "test-output", Type: "elasticsearch",
+				Tuning: &logging.OutputTuningSpec{Buffer: &logging.OutputBufferSpec{Type: logging.OutputBufferTypeDisk}}},
+			want: false,
+		},
+		{
+			name:    "With fluentd cloudwatch gzip compression",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output: &logging.OutputSpec{Name: "test-output", Type: "cloudwatch",
+				Tuning: &logging.OutputTuningSpec{Compression: "gzip"}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			clusterRequest := &ClusterLoggingRequest{
+				Cluster: tt.cluster,
+			}
+			conds := logging.NamedConditions{}
+			if got := clusterRequest.verifyOutputTuning(tt.output, conds); got != tt.want {
+				t.Errorf("verifyOutputTuning() = %v, want %v, conditions %v", got, tt.want, conds)
+			}
+		})
+	}
+}
+
+func TestVerifyParser(t *testing.T) {
+	tests := []struct {
+		name          string
+		collectorType logging.LogCollectionType
+		parser        *logging.PipelineParserSpec
+		valid         bool
+	}{
+		{"Without parser", logging.LogCollectionTypeFluentd, nil, true},
+		{"With json parser", logging.LogCollectionTypeFluentd, &logging.PipelineParserSpec{Type: logging.ParserTypeJSON}, true},
+		{"With vector logfmt parser", logging.LogCollectionTypeVector, &logging.PipelineParserSpec{Type: logging.ParserTypeLogfmt}, true},
+		{"With fluentd logfmt parser", logging.LogCollectionTypeFluentd, &logging.PipelineParserSpec{Type: logging.ParserTypeLogfmt}, false},
+		{"With regex parser", logging.LogCollectionTypeVector, &logging.PipelineParserSpec{Type: logging.ParserTypeRegex, Pattern: `^(?P<level>\w+) (?<msg>.*)$`}, true},
+		{"With regex parser without pattern", logging.LogCollectionTypeVector, &logging.PipelineParserSpec{Type: logging.ParserTypeRegex}, false},
+		{"With regex parser with invalid pattern", logging.LogCollectionTypeVector, &logging.PipelineParserSpec{Type: logging.ParserTypeRegex, Pattern: `^(?P<level>\w+`}, false},
+		{"With regex parser without named group", logging.LogCollectionTypeVector, &logging.PipelineParserSpec{Type: logging.ParserTypeRegex, Pattern: `^(\w+) (.*)$`}, false},
+		{"With csv parser", logging.LogCollectionTypeFluentd, &logging.PipelineParserSpec{Type: logging.ParserTypeCSV, Headers: []string{"a", "b"}}, true},
+		{"With csv parser without headers", logging.LogCollectionTypeFluentd, &logging.PipelineParserSpec{Type: logging.ParserTypeCSV}, false},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyParser(tt.parser, tt.collectorType); (err == nil) != tt.valid {
+				t.Errorf("verifyParser() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestVerifyTimestamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  *logging.TimestampSpec
+		valid bool
+	}{
+		{"Without timestamp", nil, true},
+		{"With field", &logging.TimestampSpec{Field: "structured.time", Format: "%d/%b/%Y:%H:%M:%S %z"}, true},
+		{"With pattern", &logging.TimestampSpec{Pattern: `^\[(?<timestamp>[^\]]+)\]`}, true},
+		{"Without field or pattern", &logging.TimestampSpec{Format: "%d/%b/%Y"}, false},
+		{"With field and pattern", &logging.TimestampSpec{Field: "time", Pattern: `^(?P<timestamp>\S+)`}, false},
+		{"With invalid pattern", &logging.TimestampSpec{Pattern: `^(?P<timestamp>\S+`}, false},
+		{"With pattern without timestamp group", &logging.TimestampSpec{Pattern: `^(?P<time>\S+)`}, false},
+		{"With timezone", &logging.TimestampSpec{Field: "time", Format: "%d/%b/%Y:%H:%M:%S", Timezone: "Europe/Paris"}, true},
+		{"With timezone without format", &logging.TimestampSpec{Field: "time", Timezone: "Europe/Paris"}, false},
+		{"With unknown timezone", &logging.TimestampSpec{Field: "time", Format: "%d/%b/%Y:%H:%M:%S", Timezone: "Mars/Olympus"}, false},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyTimestamp(tt.spec); (err == nil) != tt.valid {
+				t.Errorf("verifyTimestamp() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestVerifyLevelDetection(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  *logging.LevelDetectionSpec
+		valid bool
+	}{
+		{"Without level detection", nil, true},
+		{"Without rules", &logging.LevelDetectionSpec{}, false},
+		{"With field rule", &logging.LevelDetectionSpec{Rules: []logging.LevelDetectionRule{{Field: "structured.lvl"}}}, true},
+		{"With pattern and level", &logging.LevelDetectionSpec{Rules: []logging.LevelDetectionRule{{Pattern: `\bERROR\b`, Level: "error"}}}, true},
+		{"With pattern capturing the level", &logging.LevelDetectionSpec{Rules: []logging.LevelDetectionRule{{Pattern: `^(?<level>[IWEF])\d{4}`}}}, true},
+		{"With pattern without level", &logging.LevelDetectionSpec{Rules: []logging.LevelDetectionRule{{Pattern: `^([IWEF])\d{4}`}}}, false},
+		{"With invalid pattern", &logging.LevelDetectionSpec{Rules: []logging.LevelDetectionRule{{Pattern: `^(`, Level: "error"}}}, false},
+		{"With empty rule", &logging.LevelDetectionSpec{Rules: []logging.LevelDetectionRule{{}}}, false},
+		{"With field and pattern", &logging.LevelDetectionSpec{Rules: []logging.LevelDetectionRule{{Field: "lvl", Pattern: "error", Level: "error"}}}, false},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyLevelDetection(tt.spec); (err == nil) != tt.valid {
+				t.Errorf("verifyLevelDetection() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestVerifyEnrichment(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          *logging.EnrichmentSpec
+		collectorType logging.LogCollectionType
+		valid         bool
+	}{
+		{"Without enrichment", nil, logging.LogCollectionTypeFluentd, true},
+		{"With fluentd namespace labels", &logging.EnrichmentSpec{NamespaceLabels: utils.GetBool(true)}, logging.LogCollectionTypeFluentd, true},
+		{"With fluentd workload", &logging.EnrichmentSpec{Workload: true}, logging.LogCollectionTypeFluentd, false},
+		{"With fluentd node labels", &logging.EnrichmentSpec{NodeLabels: []string{"topology.kubernetes.io/zone"}}, logging.LogCollectionTypeFluentd, false},
+		{"With vector workload and node labels", &logging.EnrichmentSpec{Workload: true, NodeLabels: []string{"topology.kubernetes.io/zone"}}, logging.LogCollectionTypeVector, true},
+		{"With empty node label key", &logging.EnrichmentSpec{NodeLabels: []string{""}}, logging.LogCollectionTypeVector, false},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyEnrichment(tt.spec, tt.collectorType); (err == nil) != tt.valid {
+				t.Errorf("verifyEnrichment() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestVerifyFileInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  *logging.File
+		valid bool
+	}{
+		{"Without file input", nil, true},
+		{"With globs below /var/log", &logging.File{Paths: []string{"/var/log/vendor/*.log", "/var/log/other/app.log"}, Excludes: []string{"/var/log/vendor/debug*.log"}}, true},
+		{"With a valid multiline start pattern", &logging.File{Paths: []string{"/var/log/vendor/*.log"}, MultilineStartPattern: `^\d{4}-`}, true},
+		{"Without paths", &logging.File{}, false},
+		{"With a relative path", &logging.File{Paths: []string{"vendor/*.log"}}, false},
+		{"With a path outside /var/log", &logging.File{Paths: []string{"/etc/*.conf"}}, false},
+		{"With a path escaping /var/log", &logging.File{Paths: []string{"/var/log/../../etc/shadow"}}, false},
+		{"With a glob directly in /var/log", &logging.File{Paths: []string{"/var/log/*.log"}}, false},
+		{"With container logs", &logging.File{Paths: []string{"/var/log/pods/*/*/*.log"}}, false},
+		{"With container log links", &logging.File{Paths: []string{"/var/log/containers/*.log"}}, false},
+		{"With linux audit logs", &logging.File{Paths: []string{"/var/log/audit/audit.log"}}, false},
+		{"With API audit logs", &logging.File{Paths: []string{"/var/log/kube-apiserver/*.log"}}, false},
+		{"With OVN audit logs", &logging.File{Paths: []string{"/var/log/ovn/*.log"}}, false},
+		{"With other OVN logs", &logging.File{Paths: []string{"/var/log/ovn/ovn-controller.log"}}, true},
+		{"With a relative exclude", &logging.File{Paths: []string{"/var/log/vendor/*.log"}, Excludes: []string{"debug.log"}}, false},
+		{"With an invalid multiline start pattern", &logging.File{Paths: []string{"/var/log/vendor/*.log"}, MultilineStartPattern: "("}, false},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyFileInput(tt.file); (err == nil) != tt.valid {
+				t.Errorf("verifyFileInput() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestVerifyHostAudit(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          *logging.HostAuditSpec
+		collectorType logging.LogCollectionType
+		valid         bool
+	}{
+		{"Without host audit", nil, logging.LogCollectionTypeFluentd, true},
+		{"With fluentd correlation", &logging.HostAuditSpec{Correlate: true}, logging.LogCollectionTypeFluentd, false},
+		{"With vector correlation", &logging.HostAuditSpec{Correlate: true}, logging.LogCollectionTypeVector, true},
+		{"With vector correlation window", &logging.HostAuditSpec{Correlate: true, CorrelationWindow: 5}, logging.LogCollectionTypeVector, true},
+		{"With a too long correlation window", &logging.HostAuditSpec{Correlate: true, CorrelationWindow: 120}, logging.LogCollectionTypeVector, false},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyHostAudit(tt.spec, tt.collectorType); (err == nil) != tt.valid {
+				t.Errorf("verifyHostAudit() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestVerifyJournal(t *testing.T) {
+	kubelet := &logging.Journal{IncludeUnits: []string{"kubelet"}, MaxPriority: "info"}
+	infra := func(name string, journal *logging.Journal) logging.InputSpec {
+		return logging.InputSpec{Name: name, Infrastructure: &logging.Infrastructure{Journal: journal}}
+	}
+	pipeline := func(inputs ...string) logging.PipelineSpec {
+		return logging.PipelineSpec{InputRefs: inputs, OutputRefs: []string{logging.OutputNameDefault}}
+	}
+	tests := []struct {
+		name  string
+		spec  logging.ClusterLogForwarderSpec
+		valid bool
+	}{
+		{
+			"With a single filtered input",
+			logging.ClusterLogForwarderSpec{Inputs: []logging.InputSpec{infra("nodes", kubelet)}, Pipelines: []logging.PipelineSpec{pipeline("nodes")}},
+			true,
+		},
+		{
+			"With inputs having the same filter",
+			logging.ClusterLogForwarderSpec{
+				Inputs:    []logging.InputSpec{infra("nodes", kubelet), infra("other", &logging.Journal{IncludeUnits: []string{"kubelet"}, MaxPriority: "info"})},
+				Pipelines: []logging.PipelineSpec{pipeline("nodes"), pipeline("other")},
+			},
+			true,
+		},
+		{
+			"With an unreferenced input having another filter",
+			logging.ClusterLogForwarderSpec{
+				Inputs:    []logging.InputSpec{infra("nodes", kubelet), infra("other", &logging.Journal{MaxPriority: "err"})},
+				Pipelines: []logging.PipelineSpec{pipeline("nodes")},
+			},
+			true,
+		},
+		{
+			"With inputs having different filters",
+			logging.ClusterLogForwarderSpec{
+				Inputs:    []logging.InputSpec{infra("nodes", kubelet), infra("other", &logging.Journal{MaxPriority: "err"})},
+				Pipelines: []logging.PipelineSpec{pipeline("nodes", "other")},
+			},
+			false,
+		},
+		{
+			"With an input collecting the whole journal",
+			logging.ClusterLogForwarderSpec{
+				Inputs:    []logging.InputSpec{infra("nodes", kubelet), infra("other", nil)},
+				Pipelines: []logging.PipelineSpec{pipeline("nodes"), pipeline("other")},
+			},
+			false,
+		},
+		{
+			"With the infrastructure input",
+			logging.ClusterLogForwarderSpec{
+				Inputs:    []logging.InputSpec{infra("nodes", kubelet)},
+				Pipelines: []logging.PipelineSpec{pipeline("nodes"), pipeline(logging.InputNameInfrastructure)},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyJournal("nodes", tt.spec); (err == nil) != tt.valid {
+				t.Errorf("verifyJournal() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestClusterLoggingRequest_verifyReceiver(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "clients", Namespace: aNamespace},
+		Data:       map[string][]byte{constants.BearerTokenFileKey: []byte("s3cr3t")},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: aNamespace},
+		Data:       map[string][]byte{constants.TrustedCABundleKey: []byte("-- cert --")},
+	}
+	emptySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: aNamespace},
+		Data:       map[string][]byte{"other": []byte("value")},
+	}
+	receiver := func(port int32, secretName string) *logging.ReceiverSpec {
+		return &logging.ReceiverSpec{Type: logging.ReceiverTypeHTTP, Port: port, Secret: &logging.OutputSecretSpec{Name: secretName}}
+	}
+	tests := []struct {
+		name     string
+		input    string
+		receiver *logging.ReceiverSpec
+		valid    bool
+	}{
+		{"With client certificates", "myapp", receiver(8443, "ca"), true},
+		{"With a token", "myapp", receiver(8443, "clients"), false},
+		{"With a name invalid for a service", "my_app", receiver(8443, "ca"), false},
+		{"With an unsupported type", "myapp", &logging.ReceiverSpec{Type: "other", Port: 8443, Secret: &logging.OutputSecretSpec{Name: "clients"}}, false},
+		{"With a port used by another receiver", "myapp", receiver(9443, "ca"), false},
+		{"With the metrics port of the collector", "myapp", receiver(24231, "ca"), false},
+		{"With a privileged port", "myapp", receiver(443, "ca"), false},
+		{"Without secret", "myapp", &logging.ReceiverSpec{Type: logging.ReceiverTypeHTTP, Port: 8443}, false},
+		{"With a missing secret", "myapp", receiver(8443, "missing"), false},
+		{"With a secret without CA bundle", "myapp", receiver(8443, "empty"), false},
+		{"Audit webhook with client certificates", "hosted-audit", &logging.ReceiverSpec{Type: logging.ReceiverTypeKubeAPIAudit, Port: 8443, Secret: &logging.OutputSecretSpec{Name: "ca"}}, true},
+		{"Audit webhook with a token", "hosted-audit", &logging.ReceiverSpec{Type: logging.ReceiverTypeKubeAPIAudit, Port: 8443, Secret: &logging.OutputSecretSpec{Name: "clients"}}, false},
+		{"Audit webhook settings on an http receiver", "myapp", &logging.ReceiverSpec{Type: logging.ReceiverTypeHTTP, Port: 8443, Secret: &logging.OutputSecretSpec{Name: "ca"}, KubeAPIAudit: &logging.KubeAPIAuditReceiver{}}, false},
+		{"Audit webhook without secret", "hosted-audit", &logging.ReceiverSpec{Type: logging.ReceiverTypeKubeAPIAudit, Port: 8443}, false},
+		{"Syslog without secret", "appliances", &logging.ReceiverSpec{Type: logging.ReceiverTypeSyslog, Port: 6514}, true},
+		{"Syslog with client certificates", "appliances", &logging.ReceiverSpec{Type: logging.ReceiverTypeSyslog, Port: 6514, Secret: &logging.OutputSecretSpec{Name: "ca"}}, true},
+		{"Syslog with a token", "appliances", &logging.ReceiverSpec{Type: logging.ReceiverTypeSyslog, Port: 6514, Secret: &logging.OutputSecretSpec{Name: "clients"}}, false},
+		{"Syslog over udp with a secret", "appliances", &logging.ReceiverSpec{Type: logging.ReceiverTypeSyslog, Port: 5514, Syslog: &logging.SyslogReceiver{Protocol: "udp"}, Secret: &logging.OutputSecretSpec{Name: "ca"}}, false},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			request := &ClusterLoggingRequest{
+				Client:        fake.NewFakeClient(secret, caSecret, emptySecret), //nolint
+				Cluster:       &logging.ClusterLogging{ObjectMeta: metav1.ObjectMeta{Namespace: aNamespace}},
+				OutputSecrets: map[string]*corev1.Secret{},
+			}
+			err := request.verifyReceiver(tt.input, tt.receiver, map[int32]string{9443: "other"})
+			if (err == nil) != tt.valid {
+				t.Errorf("verifyReceiver() error = %v, want valid %v", err, tt.valid)
+			}
+			if _, found := request.OutputSecrets[helpers.ReceiverSecretKey(tt.input)]; found != (tt.valid && tt.receiver.Secret != nil) {
+				t.Errorf("verifyReceiver() retained secret %v, want %v", found, tt.valid)
+			}
+		})
+	}
+}
+
+func TestVerifyStaticFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]string
+		valid  bool
+	}{
+		{"Without static fields", nil, true},
+		{"With top level and nested fields", map[string]string{"team": "payments", "openshift.region": "eu-west-1"}, true},
+		{"With empty field", map[string]string{"": "payments"}, false},
+		{"With empty path segment", map[string]string{"openshift..region": "eu-west-1"}, false},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyStaticFields(tt.fields); (err == nil) != tt.valid {
+				t.Errorf("verifyStaticFields() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestVerifyStructuredFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  *logging.StructuredFieldsSpec
+		valid bool
+	}{
+		{"Without structured fields", nil, true},
+		{"With top level and nested fields", &logging.StructuredFieldsSpec{Types: map[string]logging.FieldType{"status": logging.FieldTypeInteger, "request.bytes": logging.FieldTypeFloat}}, true},
+		{"With empty field", &logging.StructuredFieldsSpec{Types: map[string]logging.FieldType{"": logging.FieldTypeString}}, false},
+		{"With empty path segment", &logging.StructuredFieldsSpec{Types: map[string]logging.FieldType{"request..bytes": logging.FieldTypeFloat}}, false},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyStructuredFields(tt.spec); (err == nil) != tt.valid {
+				t.Errorf("verifyStructuredFields() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestClusterLoggingRequest_verifyOutputURLs(t *testing.T) {
+	clusterWith := func(collectorType logging.LogCollectionType) *logging.ClusterLogging {
+		return &logging.ClusterLogging{
+			Spec: logging.ClusterLoggingSpec{
+				Collection: &logging.CollectionSpec{Type: collectorType},
+			},
+		}
+	}
+	urls := []string{"https://es-0.svc:9200", "https://es-1.svc:9200"}
+	tests := []struct {
+		name    string
+		cluster *logging.ClusterLogging
+		output  *logging.OutputSpec
+		want    bool
+	}{
+		{
+			name:    "With fluentd elasticsearch round robin urls",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "elasticsearch", URLs: urls},
+			want:    true,
+		},
+		{
+			name:    "With vector elasticsearch round robin urls",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "elasticsearch", URLs: urls},
+			want:    false,
+		},
+		{
+			name:    "With fluentd syslog round robin urls",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output: &logging.OutputSpec{Name: "test-output", Type: "syslog",
+				URLs: []string{"tcp://syslog-0.svc:514", "tcp://syslog-1.svc:514"}, Strategy: logging.OutputURLStrategyRoundRobin},
+			want: false,
+		},
+		{
+			name:    "With fluentd loki round robin urls",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "loki", URLs: []string{"https://loki-0.svc:3100", "https://loki-1.svc:3100"}},
+			want:    false,
+		},
+		{
+			name:    "With fluentd fluentdForward failover urls",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output: &logging.OutputSpec{Name: "test-output", Type: "fluentdForward",
+				URLs: []string{"tcp://fluentd-0.svc:24224", "tcp://fluentd-1.svc:24224"}, Strategy: logging.OutputURLStrategyFailover},
+			want: true,
+		},
+		{
+			name:    "With vector elasticsearch failover urls",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "elasticsearch", URLs: urls, Strategy: logging.OutputURLStrategyFailover},
+			want:    false,
+		},
+		{
+			name:    "With kafka urls",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "kafka", URLs: []string{"tcp://kafka-0.svc:9092"}},
+			want:    false,
+		},
+		{
+			name:    "With url and urls",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "elasticsearch", URL: urls[0], URLs: urls},
+			want:    false,
+		},
+		{
+			name:    "With a relative url",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "elasticsearch", URLs: []string{urls[0], "es-1.svc:9200"}},
+			want:    false,
+		},
+		{
+			name:    "With urls of different schemes",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "elasticsearch", URLs: []string{urls[0], "http://es-1.svc:9200"}},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			clusterRequest := &ClusterLoggingRequest{
+				Cluster: tt.cluster,
+			}
+			conds := logging.NamedConditions{}
+			if got := clusterRequest.verifyOutputURL(tt.output, conds); got != tt.want {
+				t.Errorf("verifyOutputURL() = %v, want %v, conditions %v", got, tt.want, conds)
+			}
+			if tt.want && tt.output.URL != tt.output.URLs[0] {
+				t.Errorf("verifyOutputURL() url = %q, want %q", tt.output.URL, tt.output.URLs[0])
+			}
+		})
+	}
+}
+
+func TestClusterLoggingRequest_verifyOutputFallback(t *testing.T) {
+	clusterWith := func(collectorType logging.LogCollectionType) *logging.ClusterLogging {
+		return &logging.ClusterLogging{
+			Spec: logging.ClusterLoggingSpec{
+				Collection: &logging.CollectionSpec{Type: collectorType},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		cluster *logging.ClusterLogging
+		output  *logging.OutputSpec
+		want    bool
+	}{
+		{
+			name:    "Without fallback",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "loki"},
+			want:    true,
+		},
+		{
+			name:    "With vector loki fallback",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "loki", FallbackOutputRef: "dead-letter"},
+			want:    false,
+		},
+		{
+			name:    "With fluentd elasticsearch fallback",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "elasticsearch", FallbackOutputRef: "dead-letter"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			clusterRequest := &ClusterLoggingRequest{Cluster: tt.cluster}
+			conds := logging.NamedConditions{}
+			if got := clusterRequest.verifyOutputFallback(tt.output, conds); got != tt.want {
+				t.Errorf("verifyOutputFallback() = %v, want %v, conditions %v", got, tt.want, conds)
+			}
+		})
+	}
+}
+
+func TestClusterLoggingRequest_verifyOutputDataModel(t *testing.T) {
+	clusterWith := func(collectorType logging.LogCollectionType) *logging.ClusterLogging {
+		return &logging.ClusterLogging{
+			Spec: logging.ClusterLoggingSpec{
+				Collection: &logging.CollectionSpec{Type: collectorType},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		cluster *logging.ClusterLogging
+		output  *logging.OutputSpec
+		want    bool
+	}{
+		{
+			name:    "Without data model",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "loki"},
+			want:    true,
+		},
+		{
+			name:    "With ViaQ data model",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "cloudwatch", DataModel: logging.DataModelViaQ},
+			want:    true,
+		},
+		{
+			name:    "With vector elasticsearch OpenTelemetry data model",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "elasticsearch", DataModel: logging.DataModelOTel},
+			want:    true,
+		},
+		{
+			name:    "With fluentd fluentdForward ECS data model",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "fluentdForward", DataModel: logging.DataModelECS},
+			want:    true,
+		},
+		{
+			name:    "With vector fluentdForward ECS data model",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "fluentdForward", DataModel: logging.DataModelECS},
+			want:    false,
+		},
+		{
+			name:    "With fluentd loki OpenTelemetry data model",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			output:  &logging.OutputSpec{Name: "test-output", Type: "loki", DataModel: logging.DataModelOTel},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			clusterRequest := &ClusterLoggingRequest{Cluster: tt.cluster}
+			conds := logging.NamedConditions{}
+			if got := clusterRequest.verifyOutputDataModel(tt.output, conds); got != tt.want {
+				t.Errorf("verifyOutputDataModel() = %v, want %v, conditions %v", got, tt.want, conds)
+			}
+		})
+	}
+}
+
+func TestClusterLoggingRequest_verifyDeliveryMode(t *testing.T) {
+	tests := []struct {
+		collectorType logging.LogCollectionType
+		outputType    string
+		valid         bool
+	}{
+		{logging.LogCollectionTypeVector, logging.OutputTypeElasticsearch, true},
+		{logging.LogCollectionTypeVector, logging.OutputTypeKafka, true},
+		{logging.LogCollectionTypeVector, logging.OutputTypeSyslog, false},
+		{logging.LogCollectionTypeFluentd, logging.OutputTypeElasticsearch, false},
+	}
+	for _, tt := range tests {
+		output := logging.OutputSpec{
+			Name: "X",
+			Type: tt.outputType,
+			URL:  "https://local.svc",
+			Tuning: &logging.OutputTuningSpec{
+				DeliveryMode: logging.OutputDeliveryModeAtLeastOnce,
+			},
+		}
+		clf := logging.ClusterLogForwarderSpec{
+			Pipelines: []logging.PipelineSpec{
+				{
+					InputRefs:  []string{logging.InputNameAudit},
+					OutputRefs: []string{"X"},
+				},
+			},
+			Outputs: []logging.OutputSpec{output},
+		}
+		g := forwardergenerator.New(tt.collectorType)
+		err := g.Verify(nil, nil, &clf, generator.Options{})
+		if tt.valid && err != nil {
+			t.Errorf("%s output with %s collector: want no error, got %v", tt.outputType, tt.collectorType, err)
+		}
+		if !tt.valid && (err == nil || err.Error() != forwarder.ErrDeliveryMode(output, tt.collectorType).Error()) {
+			t.Errorf("%s output with %s collector: want %v, got %v", tt.outputType, tt.collectorType, forwarder.ErrDeliveryMode(output, tt.collectorType), err)
+		}
+	}
+}
+
+func TestClusterLoggingRequest_verifyLogMetrics(t *testing.T) {
+	clusterWith := func(collectorType logging.LogCollectionType) *logging.ClusterLogging {
+		return &logging.ClusterLogging{
+			Spec: logging.ClusterLoggingSpec{
+				Collection: &logging.CollectionSpec{Type: collectorType},
+			},
+		}
+	}
+	counter := func(name string, pipelines ...string) logging.LogMetricSpec {
+		return logging.LogMetricSpec{Name: name, Type: logging.LogMetricTypeCounter, PipelineRefs: pipelines}
+	}
+	tests := []struct {
+		name    string
+		cluster *logging.ClusterLogging
+		metrics []logging.LogMetricSpec
+		want    []string
+		invalid bool
+	}{
+		{
+			name:    "With a counter",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			metrics: []logging.LogMetricSpec{counter("records_total", "app")},
+			want:    []string{"records_total"},
+		},
+		{
+			name:    "With a fluentd counter",
+			cluster: clusterWith(logging.LogCollectionTypeFluentd),
+			metrics: []logging.LogMetricSpec{counter("records_total", "app")},
+			invalid: true,
+		},
+		{
+			name:    "With an unknown pipeline",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			metrics: []logging.LogMetricSpec{counter("records_total", "app"), counter("infra_records_total", "infra")},
+			want:    []string{"records_total"},
+			invalid: true,
+		},
+		{
+			name:    "With duplicate names",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			metrics: []logging.LogMetricSpec{counter("records_total", "app"), counter("records_total", "app")},
+			want:    []string{"records_total"},
+			invalid: true,
+		},
+		{
+			name:    "With a histogram",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			metrics: []logging.LogMetricSpec{{Name: "duration_seconds", Type: logging.LogMetricTypeHistogram, PipelineRefs: []string{"app"}, Field: "structured.duration"}},
+			want:    []string{"duration_seconds"},
+		},
+		{
+			name:    "With a histogram without field",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			metrics: []logging.LogMetricSpec{{Name: "duration_seconds", Type: logging.LogMetricTypeHistogram, PipelineRefs: []string{"app"}}},
+			invalid: true,
+		},
+		{
+			name:    "With an invalid match",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			metrics: []logging.LogMetricSpec{{Name: "errors_total", Type: logging.LogMetricTypeCounter, PipelineRefs: []string{"app"}, Match: "error("}},
+			invalid: true,
+		},
+		{
+			name:    "With labels",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			metrics: []logging.LogMetricSpec{{Name: "records_total", Type: logging.LogMetricTypeCounter, PipelineRefs: []string{"app"},
+				Labels: map[string]string{"namespace": "kubernetes.namespace_name", "level": "level"}}},
+			want: []string{"records_total"},
+		},
+		{
+			name:    "With an invalid label name",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			metrics: []logging.LogMetricSpec{{Name: "records_total", Type: logging.LogMetricTypeCounter, PipelineRefs: []string{"app"},
+				Labels: map[string]string{"app.name": "kubernetes.labels.app"}}},
+			invalid: true,
+		},
+		{
+			name:    "With an invalid label field",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			metrics: []logging.LogMetricSpec{{Name: "records_total", Type: logging.LogMetricTypeCounter, PipelineRefs: []string{"app"},
+				Labels: map[string]string{"app": "kubernetes..app"}}},
+			invalid: true,
+		},
+		{
+			name:    "With a label of an unbounded field",
+			cluster: clusterWith(logging.LogCollectionTypeVector),
+			metrics: []logging.LogMetricSpec{{Name: "records_total", Type: logging.LogMetricTypeCounter, PipelineRefs: []string{"app"},
+				Labels: map[string]string{"message": "message"}}},
+			invalid: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Don't bind range variable.
+		t.Run(tt.name, func(t *testing.T) {
+			clusterRequest := &ClusterLoggingRequest{
+				Cluster:       tt.cluster,
+				ForwarderSpec: logging.ClusterLogForwarderSpec{LogMetrics: tt.metrics},
+			}
+			spec := &logging.ClusterLogForwarderSpec{Pipelines: []logging.PipelineSpec{{Name: "app"}}}
+			err := clusterRequest.verifyLogMetrics(spec)
+			if (err != nil) != tt.invalid {
+				t.Errorf("verifyLogMetrics() error = %v, want invalid %v", err, tt.invalid)
+			}
+			got := []string{}
+			for _, m := range spec.LogMetrics {
+				got = append(got, m.Name)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("verifyLogMetrics() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package k8shandler
+
+import (
+	"context"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// readPodFiles resolves the files listed by the annotations of the scheduled pods of all namespaces.
+// Pods are read from the API server since the cache only holds objects of the operator namespace.
+func (clusterRequest *ClusterLoggingRequest) readPodFiles() ([]helpers.PodFiles, error) {
+	var reader client.Reader = clusterRequest.Client
+	if clusterRequest.Reader != nil {
+		reader = clusterRequest.Reader
+	}
+	pods := &corev1.PodList{}
+	if err := reader.List(context.TODO(), pods); err != nil {
+		return nil, err
+	}
+	files := []helpers.PodFiles{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if _, found := pod.Annotations[logging.PodFilesAnnotation]; !found || pod.Spec.NodeName == "" {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		files = append(files, helpers.ResolvePodFiles(pod)...)
+	}
+	helpers.SortPodFiles(files)
+	return files, nil
+}
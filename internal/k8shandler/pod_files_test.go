@@ -0,0 +1,83 @@
+package k8shandler
+
+import (
+	"reflect"
+	"testing"
+
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClusterLoggingRequest_readPodFiles(t *testing.T) {
+	newPod := func(name, annotation string, phase corev1.PodPhase) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "myproject",
+				UID:       types.UID("uid-" + name),
+				Labels:    map[string]string{"app": "legacy"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node-0",
+				Volumes: []corev1.Volume{
+					{Name: "logs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+				},
+				Containers: []corev1.Container{
+					{
+						Name: "app",
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "logs", MountPath: "/data/logs"},
+							{Name: "config", MountPath: "/data/config"},
+						},
+					},
+					{
+						Name:         "reader",
+						VolumeMounts: []corev1.VolumeMount{{Name: "logs", MountPath: "/logs"}},
+					},
+				},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+		if annotation != "" {
+			pod.Annotations = map[string]string{logging.PodFilesAnnotation: annotation}
+		}
+		return pod
+	}
+	request := &ClusterLoggingRequest{
+		Client: fake.NewFakeClient( //nolint
+			newPod("annotated", "/data/logs/*.log, /data/config/app.log, relative.log, /logs/reader/*.log", corev1.PodRunning),
+			newPod("completed", "/data/logs/*.log", corev1.PodSucceeded),
+			newPod("plain", "", corev1.PodRunning),
+		),
+	}
+	files, err := request.readPodFiles()
+	if err != nil {
+		t.Fatalf("readPodFiles() error = %v", err)
+	}
+	want := []helpers.PodFiles{
+		{
+			Namespace: "myproject",
+			Pod:       "annotated",
+			PodID:     "uid-annotated",
+			Container: "app",
+			Labels:    map[string]string{"app": "legacy"},
+			Globs:     []string{"/var/lib/kubelet/pods/uid-annotated/volumes/kubernetes.io~empty-dir/logs/*.log"},
+		},
+		{
+			Namespace: "myproject",
+			Pod:       "annotated",
+			PodID:     "uid-annotated",
+			Container: "reader",
+			Labels:    map[string]string{"app": "legacy"},
+			Globs:     []string{"/var/lib/kubelet/pods/uid-annotated/volumes/kubernetes.io~empty-dir/logs/reader/*.log"},
+		},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("readPodFiles() = %v, want %v", files, want)
+	}
+}
@@ -0,0 +1,101 @@
+package k8shandler
+
+import (
+	"fmt"
+
+	log "github.com/ViaQ/logerr/v2/log/static"
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/collector"
+	"github.com/openshift/cluster-logging-operator/internal/constants"
+	"github.com/openshift/cluster-logging-operator/internal/factory"
+	genhelper "github.com/openshift/cluster-logging-operator/internal/generator/helpers"
+	"github.com/openshift/cluster-logging-operator/internal/utils"
+	"github.com/openshift/cluster-logging-operator/internal/utils/comparators/services"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+)
+
+// receiverServiceLabel identifies the services exposing receiver inputs
+const receiverServiceLabel = "logging.openshift.io/receiver"
+
+// reconcileReceiverServices exposes each receiver input with a service and removes the services of receivers
+// that are no longer used
+func (clusterRequest *ClusterLoggingRequest) reconcileReceiverServices() error {
+	desired := sets.NewString()
+	for _, input := range genhelper.ReceiverInputs(&clusterRequest.ForwarderSpec) {
+		service := newReceiverService(clusterRequest.Cluster.Namespace, input.Name, input.Receiver)
+		utils.AddOwnerRefToObject(service, utils.AsOwner(clusterRequest.Cluster))
+		if err := clusterRequest.createOrUpdateService(service); err != nil {
+			return fmt.Errorf("Failure reconciling the %q receiver service: %v", service.Name, err)
+		}
+		desired.Insert(service.Name)
+	}
+	return clusterRequest.removeReceiverServices(desired)
+}
+
+// removeReceiverServices removes the receiver services not in keep
+func (clusterRequest *ClusterLoggingRequest) removeReceiverServices(keep sets.String) error {
+	current := &v1.ServiceList{}
+	if err := clusterRequest.List(map[string]string{receiverServiceLabel: "true"}, current); err != nil {
+		return fmt.Errorf("Failure listing the receiver services: %v", err)
+	}
+	for i := range current.Items {
+		if keep.Has(current.Items[i].Name) {
+			continue
+		}
+		log.V(3).Info("Removing receiver service", "name", current.Items[i].Name)
+		if err := clusterRequest.Delete(&current.Items[i]); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("Failure deleting the %q receiver service: %v", current.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+func newReceiverService(namespace, input string, receiver *logging.ReceiverSpec) *v1.Service {
+	name := genhelper.ReceiverServiceName(input)
+	port := receiver.Port
+	service := factory.NewService(
+		name,
+		namespace,
+		constants.CollectorName,
+		[]v1.ServicePort{
+			{
+				Port:       port,
+				Protocol:   collector.ReceiverProtocol(receiver),
+				TargetPort: intstr.FromString(collector.ReceiverPortName(port)),
+				Name:       collector.ReceiverPortName(port),
+			},
+		},
+	)
+	service.Labels[receiverServiceLabel] = "true"
+	service.Annotations = map[string]string{
+		constants.AnnotationServingCertSecretName: name,
+	}
+	return service
+}
+
+func (clusterRequest *ClusterLoggingRequest) createOrUpdateService(desired *v1.Service) error {
+	err := clusterRequest.Create(desired)
+	if err == nil || !errors.IsAlreadyExists(err) {
+		return err
+	}
+	current := &v1.Service{}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := clusterRequest.Get(desired.Name, current); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if services.AreSame(current, desired) {
+			return nil
+		}
+		current.Labels = desired.Labels
+		current.Spec.Selector = desired.Spec.Selector
+		current.Spec.Ports = desired.Spec.Ports
+		return clusterRequest.Update(current)
+	})
+}
@@ -0,0 +1,91 @@
+package k8shandler
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	loggingv1 "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Reconciling receiver services", func() {
+	var (
+		request *ClusterLoggingRequest
+		key     = client.ObjectKey{Name: "collector-myapp", Namespace: constants.OpenshiftNS}
+	)
+
+	BeforeEach(func() {
+		request = &ClusterLoggingRequest{
+			Client: fake.NewFakeClient(), //nolint
+			Cluster: &loggingv1.ClusterLogging{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: constants.OpenshiftNS},
+			},
+			ForwarderSpec: loggingv1.ClusterLogForwarderSpec{
+				Inputs: []loggingv1.InputSpec{
+					{
+						Name: "myapp",
+						Receiver: &loggingv1.ReceiverSpec{
+							Type:   loggingv1.ReceiverTypeHTTP,
+							Port:   8443,
+							Secret: &loggingv1.OutputSecretSpec{Name: "clients"},
+						},
+					},
+				},
+				Pipelines: []loggingv1.PipelineSpec{
+					{InputRefs: []string{"myapp"}, OutputRefs: []string{loggingv1.OutputNameDefault}},
+				},
+			},
+		}
+	})
+
+	It("should expose a receiver with a service signed by the service CA", func() {
+		Expect(request.reconcileReceiverServices()).To(Succeed())
+		service := &corev1.Service{}
+		Expect(request.Client.Get(context.TODO(), key, service)).To(Succeed())
+		Expect(service.Annotations).To(HaveKeyWithValue(constants.AnnotationServingCertSecretName, "collector-myapp"))
+		Expect(service.Spec.Selector).To(HaveKeyWithValue("component", constants.CollectorName))
+		Expect(service.Spec.Ports).To(HaveLen(1))
+		Expect(service.Spec.Ports[0].Port).To(BeEquivalentTo(8443))
+		Expect(service.Spec.Ports[0].TargetPort.StrVal).To(Equal("receiver-8443"))
+	})
+
+	It("should expose a syslog receiver over udp with a udp port", func() {
+		request.ForwarderSpec.Inputs[0].Receiver = &loggingv1.ReceiverSpec{
+			Type:   loggingv1.ReceiverTypeSyslog,
+			Port:   5514,
+			Syslog: &loggingv1.SyslogReceiver{Protocol: "udp"},
+		}
+		Expect(request.reconcileReceiverServices()).To(Succeed())
+		service := &corev1.Service{}
+		Expect(request.Client.Get(context.TODO(), key, service)).To(Succeed())
+		Expect(service.Spec.Ports[0].Protocol).To(Equal(corev1.ProtocolUDP))
+	})
+
+	It("should update the port of the service", func() {
+		Expect(request.reconcileReceiverServices()).To(Succeed())
+		request.ForwarderSpec.Inputs[0].Receiver.Port = 9443
+		Expect(request.reconcileReceiverServices()).To(Succeed())
+		service := &corev1.Service{}
+		Expect(request.Client.Get(context.TODO(), key, service)).To(Succeed())
+		Expect(service.Spec.Ports[0].Port).To(BeEquivalentTo(9443))
+	})
+
+	It("should remove the services of receivers no longer used", func() {
+		Expect(request.reconcileReceiverServices()).To(Succeed())
+		request.ForwarderSpec.Pipelines = nil
+		Expect(request.reconcileReceiverServices()).To(Succeed())
+		Expect(request.Client.Get(context.TODO(), key, &corev1.Service{})).ToNot(Succeed())
+	})
+
+	It("should remove all the receiver services", func() {
+		Expect(request.reconcileReceiverServices()).To(Succeed())
+		Expect(request.removeReceiverServices(sets.NewString())).To(Succeed())
+		Expect(request.Client.Get(context.TODO(), key, &corev1.Service{})).ToNot(Succeed())
+	})
+})
@@ -147,9 +147,10 @@ func removeManagedStorage(clusterRequest ClusterLoggingRequest) {
 	}
 }
 
-func ReconcileForClusterLogForwarder(forwarder *logging.ClusterLogForwarder, requestClient client.Client) (err error) {
+func ReconcileForClusterLogForwarder(forwarder *logging.ClusterLogForwarder, requestClient client.Client, reader client.Reader) (err error) {
 	clusterLoggingRequest := ClusterLoggingRequest{
 		Client: requestClient,
+		Reader: reader,
 	}
 	if forwarder != nil {
 		clusterLoggingRequest.ForwarderRequest = forwarder
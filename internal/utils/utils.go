@@ -41,6 +41,7 @@ var COMPONENT_IMAGES = map[string]string{
 	"kibana":                             "KIBANA_IMAGE",
 	constants.LogfilesmetricexporterName: constants.LogfilesmetricImageEnvVar,
 	constants.ConsolePluginName:          constants.ConsolePluginImageEnvVar,
+	constants.EventRouterName:            constants.EventRouterImageEnvVar,
 }
 
 // GetAnnotation returns the value of an annoation for a given key and true if the key was found
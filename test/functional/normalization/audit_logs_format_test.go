@@ -116,6 +116,10 @@ var _ = Describe("[Functional][LogForwarding][Normalization] message format test
 					},
 					ViaqMsgID:        "*",
 					PipelineMetadata: functional.TemplateForAnyPipelineMetadata,
+					OVN: types.OVNACL{
+						ACLName: "verify-audit-logging_deny-all",
+						Verdict: "drop",
+					},
 				}
 				outputLogTemplate.PipelineMetadata.Collector.ReceivedAt = time.Time{}
 				// Write log line as input to fluentd
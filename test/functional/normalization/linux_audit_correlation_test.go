@@ -0,0 +1,64 @@
+//go:build vector
+// +build vector
+
+package normalization
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/utils"
+	"github.com/openshift/cluster-logging-operator/test/framework/functional"
+)
+
+var _ = Describe("[Functional][LogForwarding][Normalization] correlation of linux audit records", func() {
+
+	var (
+		framework *functional.CollectorFunctionalFramework
+	)
+
+	BeforeEach(func() {
+		framework = functional.NewCollectorFunctionalFrameworkUsingCollector(logging.LogCollectionTypeVector)
+		functional.NewClusterLogForwarderBuilder(framework.Forwarder).
+			FromInput(logging.InputNameAudit).
+			ToElasticSearchOutput()
+		framework.Forwarder.Spec.HostAudit = &logging.HostAuditSpec{Correlate: true}
+		Expect(framework.Deploy()).To(BeNil())
+	})
+
+	AfterEach(func() {
+		framework.Cleanup()
+	})
+
+	It("should merge the fields of all the records of an event", func() {
+		serial := fmt.Sprintf("%.3f:24287", float64(time.Now().UnixNano())/float64(time.Second))
+		records := []string{
+			fmt.Sprintf(`type=SYSCALL msg=audit(%s): arch=c000003e syscall=2 success=no exit=-13 items=1 ppid=2686 pid=3538 auid=1000 uid=1000 comm="cat" exe="/bin/cat" key="sshd_config"`, serial),
+			fmt.Sprintf(`type=CWD msg=audit(%s): cwd="/home/shadowman"`, serial),
+			fmt.Sprintf(`type=PATH msg=audit(%s): item=0 name="/etc/ssh/sshd_config" inode=409248 dev=fd:00 mode=0100600 ouid=0 ogid=0 nametype=NORMAL`, serial),
+			fmt.Sprintf(`type=EOE msg=audit(%s):`, serial),
+		}
+		Expect(framework.WriteMessagesToAuditLog(strings.Join(records, "\n"), 1)).To(BeNil())
+
+		raw, err := framework.ReadAuditLogsFrom(logging.OutputTypeElasticsearch)
+		Expect(err).To(BeNil(), "Expected no errors reading the logs")
+		logs := []map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(utils.ToJsonLogs(raw)), &logs)).To(Succeed(), "Expected no errors parsing the logs: %v", raw)
+		Expect(logs).To(HaveLen(1), "Expected the records of the event to be merged: %v", raw)
+
+		Expect(logs[0]["message"]).To(Equal(strings.Join(records, "\n")))
+		Expect(logs[0]["audit.linux"]).To(And(
+			HaveKeyWithValue("type", "SYSCALL"),
+			HaveKeyWithValue("syscall", "2"),
+			HaveKeyWithValue("exe", "/bin/cat"),
+			HaveKeyWithValue("cwd", "/home/shadowman"),
+			HaveKeyWithValue("name", "/etc/ssh/sshd_config"),
+			HaveKeyWithValue("nametype", "NORMAL"),
+		))
+	})
+})
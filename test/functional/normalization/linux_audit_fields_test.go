@@ -0,0 +1,60 @@
+//go:build vector
+// +build vector
+
+package normalization
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/utils"
+	"github.com/openshift/cluster-logging-operator/test/framework/functional"
+)
+
+var _ = Describe("[Functional][LogForwarding][Normalization] fields of linux audit records", func() {
+
+	var (
+		framework *functional.CollectorFunctionalFramework
+	)
+
+	BeforeEach(func() {
+		framework = functional.NewCollectorFunctionalFrameworkUsingCollector(logging.LogCollectionTypeVector)
+		functional.NewClusterLogForwarderBuilder(framework.Forwarder).
+			FromInput(logging.InputNameAudit).
+			ToElasticSearchOutput()
+		Expect(framework.Deploy()).To(BeNil())
+	})
+
+	AfterEach(func() {
+		framework.Cleanup()
+	})
+
+	It("should parse the fields nested in the single quoted msg of user space records", func() {
+		serial := fmt.Sprintf("%.3f:2718", float64(time.Now().UnixNano())/float64(time.Second))
+		record := fmt.Sprintf(`type=USER_START msg=audit(%s): pid=4471 uid=0 auid=1000 ses=3 subj=system_u:system_r:sshd_t:s0-s0:c0.c1023 msg='op=PAM:session_open grantors=pam_selinux,pam_loginuid acct="shadowman" exe="/usr/sbin/sshd" hostname=10.0.0.1 addr=10.0.0.1 terminal=ssh res=success'`, serial)
+		Expect(framework.WriteMessagesToAuditLog(record, 1)).To(BeNil())
+
+		raw, err := framework.ReadAuditLogsFrom(logging.OutputTypeElasticsearch)
+		Expect(err).To(BeNil(), "Expected no errors reading the logs")
+		logs := []map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(utils.ToJsonLogs(raw)), &logs)).To(Succeed(), "Expected no errors parsing the logs: %v", raw)
+		Expect(logs).To(HaveLen(1))
+
+		Expect(logs[0]["audit.linux"]).To(And(
+			HaveKeyWithValue("type", "USER_START"),
+			HaveKeyWithValue("pid", "4471"),
+			HaveKeyWithValue("subj", "system_u:system_r:sshd_t:s0-s0:c0.c1023"),
+			HaveKeyWithValue("op", "PAM:session_open"),
+			HaveKeyWithValue("grantors", "pam_selinux,pam_loginuid"),
+			HaveKeyWithValue("acct", "shadowman"),
+			HaveKeyWithValue("exe", "/usr/sbin/sshd"),
+			HaveKeyWithValue("terminal", "ssh"),
+			HaveKeyWithValue("res", "success"),
+			Not(HaveKey("msg")),
+		))
+	})
+})
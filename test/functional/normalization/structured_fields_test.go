@@ -0,0 +1,72 @@
+//go:build vector
+// +build vector
+
+package normalization
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/test/framework/functional"
+)
+
+var _ = Describe("[Functional][Normalization] protection of structured fields from type conflicts", func() {
+
+	const message = `{"code": "200", "user": "alice", "debug": true, "latency": 1.5, "error": null, "details": {}, "tags": []}`
+
+	var (
+		framework *functional.CollectorFunctionalFramework
+	)
+
+	BeforeEach(func() {
+		framework = functional.NewCollectorFunctionalFrameworkUsingCollector(logging.LogCollectionTypeVector)
+		functional.NewClusterLogForwarderBuilder(framework.Forwarder).
+			FromInput(logging.InputNameApplication).
+			ToElasticSearchOutput()
+		framework.Forwarder.Spec.Pipelines[0].Parse = "json"
+	})
+
+	AfterEach(func() {
+		framework.Cleanup()
+	})
+
+	readStructured := func() map[string]interface{} {
+		Expect(framework.Deploy()).To(BeNil())
+		Expect(framework.WriteMessagesToApplicationLog(functional.CreateAppLogFromJson(message), 1)).To(BeNil())
+		logs, err := framework.ReadApplicationLogsFrom(logging.OutputTypeElasticsearch)
+		Expect(err).To(BeNil(), "Expected no errors reading the logs")
+		Expect(logs).To(HaveLen(1))
+		return logs[0].Structured
+	}
+
+	It("should suffix undeclared scalar fields with their type and keep null and empty values", func() {
+		framework.Forwarder.Spec.Pipelines[0].StructuredFields = &logging.StructuredFieldsSpec{
+			Types:     map[string]logging.FieldType{"code": logging.FieldTypeInteger},
+			Conflicts: logging.StructuredFieldConflictsSuffix,
+		}
+		Expect(readStructured()).To(Equal(map[string]interface{}{
+			"code":        float64(200),
+			"user_str":    "alice",
+			"debug_bool":  true,
+			"latency_num": 1.5,
+			"error":       nil,
+			"details":     map[string]interface{}{},
+			"tags":        []interface{}{},
+		}))
+	})
+
+	It("should stringify undeclared scalar fields and keep null and empty values", func() {
+		framework.Forwarder.Spec.Pipelines[0].StructuredFields = &logging.StructuredFieldsSpec{
+			Conflicts: logging.StructuredFieldConflictsStringify,
+		}
+		Expect(readStructured()).To(Equal(map[string]interface{}{
+			"code":    "200",
+			"user":    "alice",
+			"debug":   "true",
+			"latency": "1.5",
+			"error":   nil,
+			"details": map[string]interface{}{},
+			"tags":    []interface{}{},
+		}))
+	})
+})
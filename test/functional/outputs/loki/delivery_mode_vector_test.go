@@ -0,0 +1,116 @@
+//go:build vector
+// +build vector
+
+package loki
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logging "github.com/openshift/cluster-logging-operator/apis/logging/v1"
+	"github.com/openshift/cluster-logging-operator/internal/constants"
+	"github.com/openshift/cluster-logging-operator/internal/runtime"
+	"github.com/openshift/cluster-logging-operator/test/framework/functional"
+	"github.com/openshift/cluster-logging-operator/test/helpers/loki"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var _ = Describe("[Functional][Outputs][Loki] Forwarding with AtLeastOnce delivery mode", func() {
+
+	const numOfLogs = 5
+
+	var (
+		f *functional.CollectorFunctionalFramework
+		l *loki.Receiver
+	)
+
+	// persistLogsAndCheckpoints keeps the container logs and the checkpoints of the collector across
+	// restarts of its container, as the hostPath volumes of a deployed collector do
+	persistLogsAndCheckpoints := func(b *runtime.PodBuilder) error {
+		b.AddEmptyDirVolume("pods").AddEmptyDirVolume("data").
+			GetContainer(constants.CollectorName).
+			AddVolumeMount("pods", "/var/log/pods", "", false).
+			AddVolumeMount("data", "/var/lib/vector", "", false).
+			Update()
+		return nil
+	}
+
+	// restartCollector kills the collector and waits till its container is restarted
+	restartCollector := func() {
+		_, _ = f.RunCommand(constants.CollectorName, "bash", "-c", "kill -9 -1")
+		Expect(wait.PollImmediate(time.Second*2, time.Minute*2, func() (bool, error) {
+			if err := f.Test.Client.Get(f.Pod); err != nil {
+				return false, nil
+			}
+			for _, cs := range f.Pod.Status.ContainerStatuses {
+				if cs.Name == constants.CollectorName {
+					return cs.RestartCount > 0 && cs.Ready, nil
+				}
+			}
+			return false, nil
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		f = functional.NewCollectorFunctionalFrameworkUsingCollector(logging.LogCollectionTypeVector)
+		l = loki.NewReceiver(f.Namespace, "loki-server")
+		Expect(l.Create(f.Test.Client)).To(Succeed())
+
+		f.Forwarder.Spec.Outputs = append(f.Forwarder.Spec.Outputs,
+			logging.OutputSpec{
+				Name: logging.OutputTypeLoki,
+				Type: logging.OutputTypeLoki,
+				URL:  l.InternalURL("").String(),
+				OutputTypeSpec: logging.OutputTypeSpec{
+					Loki: &logging.Loki{},
+				},
+				Tuning: &logging.OutputTuningSpec{
+					DeliveryMode:     logging.OutputDeliveryModeAtLeastOnce,
+					MinRetryDuration: 1,
+					MaxRetryDuration: 5,
+				},
+			})
+		f.Forwarder.Spec.Pipelines = append(f.Forwarder.Spec.Pipelines,
+			logging.PipelineSpec{
+				OutputRefs: []string{logging.OutputTypeLoki},
+				InputRefs:  []string{logging.InputNameApplication},
+			})
+
+		Expect(f.DeployWithVisitor(persistLogsAndCheckpoints)).To(BeNil())
+	})
+
+	AfterEach(func() {
+		f.Cleanup()
+	})
+
+	It("should not lose unacknowledged logs when the collector restarts", func() {
+		query := fmt.Sprintf(`{kubernetes_namespace_name=%q, kubernetes_pod_name=%q}`, f.Namespace, f.Name)
+
+		msg := functional.NewFullCRIOLogMessage(functional.CRIOTime(time.Now()), "before restart")
+		Expect(f.WriteMessagesToApplicationLog(msg, numOfLogs)).To(Succeed())
+		_, err := l.QueryUntil(query, "", numOfLogs)
+		Expect(err).To(BeNil())
+
+		Expect(l.Stop(f.Test.Client)).To(Succeed())
+		msg = functional.NewFullCRIOLogMessage(functional.CRIOTime(time.Now()), "while receiver is down")
+		Expect(f.WriteMessagesToApplicationLog(msg, numOfLogs)).To(Succeed())
+		// Give the collector time to read the logs, which are held unacknowledged by the retrying sink
+		time.Sleep(10 * time.Second)
+		restartCollector()
+		Expect(l.Start(f.Test.Client)).To(Succeed())
+
+		result, err := l.QueryUntil(query, "", numOfLogs)
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		delivered := 0
+		for _, line := range result[0].Lines() {
+			if strings.Contains(line, "while receiver is down") {
+				delivered++
+			}
+		}
+		Expect(delivered).To(BeNumerically(">=", numOfLogs), "logs read before the restart were lost")
+	})
+})
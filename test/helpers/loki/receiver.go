@@ -74,7 +74,36 @@ func (r *Receiver) Create(c *client.Client) error {
 	if err := g.Wait(); err != nil {
 		return err
 	}
-	// Wait till we can get the metrics page, means server is up.
+	return r.waitForServer()
+}
+
+// Stop deletes the receiver's pod, keeping its service and route. Blocks till deleted.
+func (r *Receiver) Stop(c *client.Client) error {
+	if err := c.Remove(r.Pod); err != nil {
+		return err
+	}
+	return wait.PollImmediate(time.Second, c.Timeout(), func() (bool, error) {
+		err := c.Get(runtime.NewPod(r.Pod.Namespace, r.Pod.Name))
+		if err == nil {
+			return false, nil
+		}
+		return true, client.IgnoreNotFound(err)
+	})
+}
+
+// Start re-creates the receiver's pod after Stop. Blocks till the server is up.
+func (r *Receiver) Start(c *client.Client) error {
+	if err := c.Recreate(r.Pod); err != nil {
+		return err
+	}
+	if err := c.WaitFor(r.Pod, client.PodRunning); err != nil {
+		return err
+	}
+	return r.waitForServer()
+}
+
+// waitForServer waits till we can get the metrics page, means server is up.
+func (r *Receiver) waitForServer() error {
 	return wait.PollImmediate(time.Second, r.timeout, func() (bool, error) {
 		resp, err := http.Get(r.ExternalURL("/metrics").String())
 		if err == nil {
@@ -0,0 +1,112 @@
+package types
+
+import "time"
+
+// ECSLog is a container log translated to the Elastic Common Schema data model
+type ECSLog struct {
+	Timestamp        time.Time         `json:"@timestamp"`
+	Message          string            `json:"message"`
+	Log              ECSLogMeta        `json:"log"`
+	Event            ECSEvent          `json:"event"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	Host             ECSHost           `json:"host"`
+	Orchestrator     ECSOrchestrator   `json:"orchestrator"`
+	Container        ECSContainer      `json:"container"`
+	ECS              ECSVersion        `json:"ecs"`
+	Kubernetes       Kubernetes        `json:"kubernetes,omitempty"`
+	Openshift        OpenshiftMeta     `json:"openshift,omitempty"`
+	PipelineMetadata PipelineMetadata  `json:"pipeline_metadata,omitempty"`
+	ViaqMsgID        string            `json:"viaq_msg_id,omitempty"`
+}
+
+type ECSLogMeta struct {
+	Level string `json:"level"`
+}
+
+type ECSEvent struct {
+	Dataset string `json:"dataset"`
+}
+
+type ECSHost struct {
+	Name string `json:"name"`
+}
+
+type ECSOrchestrator struct {
+	Namespace string      `json:"namespace,omitempty"`
+	Resource  ECSResource `json:"resource,omitempty"`
+}
+
+type ECSResource struct {
+	Name string `json:"name,omitempty"`
+}
+
+type ECSContainer struct {
+	ID    string   `json:"id,omitempty"`
+	Name  string   `json:"name,omitempty"`
+	Image ECSImage `json:"image,omitempty"`
+}
+
+type ECSImage struct {
+	Name string `json:"name,omitempty"`
+}
+
+type ECSVersion struct {
+	Version string `json:"version"`
+}
+
+// OTelLog is a container log translated to the OpenTelemetry data model
+type OTelLog struct {
+	Timestamp        time.Time        `json:"timestamp"`
+	Body             string           `json:"body"`
+	SeverityText     string           `json:"severity_text"`
+	Attributes       OTelAttributes   `json:"attributes"`
+	Resource         OTelResource     `json:"resource"`
+	Kubernetes       Kubernetes       `json:"kubernetes,omitempty"`
+	Openshift        OpenshiftMeta    `json:"openshift,omitempty"`
+	PipelineMetadata PipelineMetadata `json:"pipeline_metadata,omitempty"`
+	ViaqMsgID        string           `json:"viaq_msg_id,omitempty"`
+}
+
+type OTelAttributes struct {
+	LogType   string        `json:"log_type"`
+	Openshift OpenshiftMeta `json:"openshift,omitempty"`
+}
+
+type OTelResource struct {
+	Host      OTelHost      `json:"host"`
+	K8s       OTelK8s       `json:"k8s,omitempty"`
+	Container OTelContainer `json:"container,omitempty"`
+}
+
+type OTelHost struct {
+	Name string `json:"name"`
+}
+
+type OTelK8s struct {
+	Namespace OTelNamespace    `json:"namespace,omitempty"`
+	Pod       OTelPod          `json:"pod,omitempty"`
+	Container OTelK8sContainer `json:"container,omitempty"`
+}
+
+type OTelNamespace struct {
+	Name string `json:"name,omitempty"`
+}
+
+type OTelPod struct {
+	Name  string            `json:"name,omitempty"`
+	UID   string            `json:"uid,omitempty"`
+	Label map[string]string `json:"label,omitempty"`
+}
+
+type OTelK8sContainer struct {
+	Name string `json:"name,omitempty"`
+}
+
+type OTelContainer struct {
+	ID    string    `json:"id,omitempty"`
+	Image OTelImage `json:"image,omitempty"`
+}
+
+type OTelImage struct {
+	Name string `json:"name,omitempty"`
+}
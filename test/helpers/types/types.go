@@ -198,6 +198,20 @@ type OVNAuditLog struct {
 	Kubernetes       Kubernetes       `json:"kubernetes"`
 	Openshift        OpenshiftMeta    `json:"openshift"`
 	Level            string           `json:"level,omitempty"`
+	OVN              OVNACL           `json:"ovn,omitempty"`
+}
+
+// OVNACL is the ACL and flow parsed from an OVN ACL audit log
+type OVNACL struct {
+	ACLName   string `json:"acl_name,omitempty"`
+	Verdict   string `json:"verdict,omitempty"`
+	Severity  string `json:"severity,omitempty"`
+	Direction string `json:"direction,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	SrcIP     string `json:"src_ip,omitempty"`
+	DstIP     string `json:"dst_ip,omitempty"`
+	SrcPort   int    `json:"src_port,omitempty"`
+	DstPort   int    `json:"dst_port,omitempty"`
 }
 
 // AuditLogCommon is common to k8s and openshift auditlogs
@@ -92,6 +92,33 @@ var _ = Describe("Log Format matcher tests", func() {
 		})
 	})
 
+	Context("for data models", func() {
+		It("should match an ECS record", func() {
+			Expect(types.ECSLog{
+				Message: "text",
+				Log:     types.ECSLogMeta{Level: "info"},
+				Event:   types.ECSEvent{Dataset: "application"},
+				ECS:     types.ECSVersion{Version: "8.0.0"},
+			}).To(FitLogFormatTemplate(types.ECSLog{
+				Message: "*",
+				Log:     types.ECSLogMeta{Level: "*"},
+				Event:   types.ECSEvent{Dataset: "application"},
+				ECS:     types.ECSVersion{Version: "*"},
+			}))
+		})
+		It("should not match an OpenTelemetry record with a wrong severity", func() {
+			Expect(types.OTelLog{
+				Body:         "text",
+				SeverityText: "info",
+				Attributes:   types.OTelAttributes{LogType: "application"},
+			}).NotTo(FitLogFormatTemplate(types.OTelLog{
+				Body:         "*",
+				SeverityText: "error",
+				Attributes:   types.OTelAttributes{LogType: "application"},
+			}))
+		})
+	})
+
 	It("do not match wrong time value", func() {
 		timestamp1 := "2013-03-28T14:36:03.243000+00:00"
 		nanoTime1, _ := time.Parse(time.RFC3339Nano, timestamp1)